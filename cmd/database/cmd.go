@@ -0,0 +1,21 @@
+package database
+
+import (
+	"github.com/spf13/cobra"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+)
+
+// NewDatabaseCmd returns the Cobra command allowing to manage the database
+func NewDatabaseCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "database",
+		Short: "Manage the database used to store the parsed data",
+	}
+
+	cmd.AddCommand(
+		NewPartitionsCmd(parseCfg),
+	)
+
+	return cmd
+}
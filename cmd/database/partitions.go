@@ -0,0 +1,187 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/database"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+// NewPartitionsCmd returns the Cobra command allowing to manage the partitions of the partitioned tables
+func NewPartitionsCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "partitions",
+		Short: "Manage the partitions of the partitioned tables",
+	}
+
+	cmd.AddCommand(
+		NewListPartitionsCmd(parseCfg),
+		NewCreatePartitionCmd(parseCfg),
+		NewDropPartitionCmd(parseCfg),
+	)
+
+	return cmd
+}
+
+// NewListPartitionsCmd returns the Cobra command allowing to list the partitions along with their rows count and size
+func NewListPartitionsCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list [table]",
+		Short:   "List the partitions of the given table, or of all the partitioned tables, along with their rows count and size",
+		Args:    cobra.RangeArgs(0, 1),
+		PreRunE: parsecmdtypes.ReadConfigPreRunE(parseCfg),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, cfg, err := getPartitionsDb(parseCfg)
+			if err != nil {
+				return err
+			}
+
+			tables := database.PartitionedTables
+			if len(args) > 0 {
+				tables = []string{args[0]}
+			}
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, "TABLE\tPARTITION\tHEIGHTS\tROWS\tSIZE")
+			for _, table := range tables {
+				partitions, err := db.GetPartitions(table)
+				if err != nil {
+					return fmt.Errorf("error while getting partitions of table %s: %s", table, err)
+				}
+
+				for _, partition := range partitions {
+					fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%s\n",
+						table,
+						partition.Name,
+						formatHeights(partition, cfg.Database.PartitionSize),
+						partition.Rows,
+						formatSize(partition.Size),
+					)
+				}
+			}
+
+			return writer.Flush()
+		},
+	}
+}
+
+// NewCreatePartitionCmd returns the Cobra command allowing to create a partition
+func NewCreatePartitionCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:     "create [table] [partition-id]",
+		Short:   "Create the partition of the given table having the given id, if not existing",
+		Args:    cobra.ExactArgs(2),
+		PreRunE: parsecmdtypes.ReadConfigPreRunE(parseCfg),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, cfg, err := getPartitionsDb(parseCfg)
+			if err != nil {
+				return err
+			}
+
+			if cfg.Database.PartitionSize <= 0 {
+				return fmt.Errorf("partitioning is disabled, please set the database partition_size first")
+			}
+
+			partitionID, err := parsePartitionID(args[1])
+			if err != nil {
+				return err
+			}
+
+			err = db.CreatePartitionIfNotExists(args[0], partitionID)
+			if err != nil {
+				return fmt.Errorf("error while creating partition: %s", err)
+			}
+
+			fmt.Printf("Partition %s_%d created\n", args[0], partitionID)
+			return nil
+		},
+	}
+}
+
+// NewDropPartitionCmd returns the Cobra command allowing to drop a partition
+func NewDropPartitionCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:     "drop [table] [partition-id]",
+		Short:   "Detach and drop the partition of the given table having the given id, deleting all the rows it contains",
+		Args:    cobra.ExactArgs(2),
+		PreRunE: parsecmdtypes.ReadConfigPreRunE(parseCfg),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, _, err := getPartitionsDb(parseCfg)
+			if err != nil {
+				return err
+			}
+
+			partitionID, err := parsePartitionID(args[1])
+			if err != nil {
+				return err
+			}
+
+			err = db.DropPartition(args[0], partitionID)
+			if err != nil {
+				return fmt.Errorf("error while dropping partition: %s", err)
+			}
+
+			fmt.Printf("Partition %s_%d dropped\n", args[0], partitionID)
+			return nil
+		},
+	}
+}
+
+// getPartitionsDb builds the database based on the configuration, making sure it supports partitions
+func getPartitionsDb(parseCfg *parsecmdtypes.Config) (database.PartitionsDb, config.Config, error) {
+	cfg, err := parsecmdtypes.ReadConfig(parseCfg)
+	if err != nil {
+		return nil, config.Config{}, err
+	}
+
+	db, err := parseCfg.GetDBBuilder()(database.NewContext(cfg.Database, parseCfg.GetLogger()))
+	if err != nil {
+		return nil, config.Config{}, fmt.Errorf("error while building database: %s", err)
+	}
+
+	partitionsDb, ok := db.(database.PartitionsDb)
+	if !ok {
+		return nil, config.Config{}, fmt.Errorf("your database does not implement PartitionsDb")
+	}
+
+	return partitionsDb, cfg, nil
+}
+
+// parsePartitionID parses the given value as a partition id
+func parsePartitionID(value string) (int64, error) {
+	partitionID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || partitionID < 0 {
+		return 0, fmt.Errorf("invalid partition id: %s", value)
+	}
+	return partitionID, nil
+}
+
+// formatHeights returns the range of heights contained inside the given partition
+func formatHeights(partition database.Partition, partitionSize int64) string {
+	if partitionSize <= 0 {
+		return "-"
+	}
+	from, to := partition.Heights(partitionSize)
+	return fmt.Sprintf("%d-%d", from, to-1)
+}
+
+// formatSize returns the given size in bytes in a human-readable format
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -7,8 +7,10 @@ import (
 
 	"github.com/forbole/juno/v5/types/config"
 
+	databasecmd "github.com/forbole/juno/v5/cmd/database"
 	initcmd "github.com/forbole/juno/v5/cmd/init"
 	migratecmd "github.com/forbole/juno/v5/cmd/migrate"
+	modulescmd "github.com/forbole/juno/v5/cmd/modules"
 	parsecmd "github.com/forbole/juno/v5/cmd/parse"
 	startcmd "github.com/forbole/juno/v5/cmd/start"
 
@@ -42,6 +44,8 @@ func BuildDefaultExecutor(config *Config) cli.Executor {
 		parsecmd.NewParseCmd(config.GetParseConfig()),
 		startcmd.NewStartCmd(config.GetParseConfig()),
 		migratecmd.NewMigrateCmd(config.GetName(), config.GetParseConfig()),
+		modulescmd.NewModulesCmd(config.GetParseConfig()),
+		databasecmd.NewDatabaseCmd(config.GetParseConfig()),
 	)
 
 	return PrepareRootCmd(config.GetName(), rootCmd)
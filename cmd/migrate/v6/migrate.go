@@ -0,0 +1,40 @@
+package v6
+
+import (
+	"fmt"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+
+	"github.com/forbole/juno/v5/database"
+	v6db "github.com/forbole/juno/v5/database/legacy/v6"
+	"github.com/forbole/juno/v5/database/postgresql"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+// RunMigration runs the migrations from v5 to v6.
+// The configuration file does not need to be migrated, while the schema of databases created by any v5.x version
+// is updated adding the module_state and address_message tables, along with the new message columns.
+func RunMigration(parseConfig *parsecmdtypes.Config) error {
+	err := parsecmdtypes.UpdatedGlobalCfg(parseConfig)
+	if err != nil {
+		return err
+	}
+
+	db, err := parseConfig.GetDBBuilder()(database.NewContext(config.Cfg.Database, parseConfig.GetLogger()))
+	if err != nil {
+		return fmt.Errorf("error while building database: %s", err)
+	}
+	defer db.Close()
+
+	psqlDb, ok := db.(*postgresql.Database)
+	if !ok {
+		return fmt.Errorf("database migrations are only supported for PostgreSQL databases")
+	}
+
+	err = v6db.NewMigrator(psqlDb).Migrate()
+	if err != nil {
+		return fmt.Errorf("error while migrating database: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package modules
+
+import (
+	"github.com/spf13/cobra"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+)
+
+// NewModulesCmd returns the Cobra command allowing to inspect the enabled modules
+func NewModulesCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "modules",
+		Short: "Inspect the modules enabled inside the configuration file",
+	}
+
+	cmd.AddCommand(
+		NewListCmd(parseCfg),
+	)
+
+	return cmd
+}
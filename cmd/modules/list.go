@@ -0,0 +1,108 @@
+package modules
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/modules"
+	nodeconfig "github.com/forbole/juno/v5/node/config"
+)
+
+// NewListCmd returns the Cobra command allowing to list the enabled modules along with the data they handle
+func NewListCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List the enabled modules, in the order in which they are called, along with the data they handle",
+		PreRunE: parsecmdtypes.ReadConfigPreRunE(parseConfig),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Read the configuration
+			cfg, err := parsecmdtypes.ReadConfig(parseConfig)
+			if err != nil {
+				return err
+			}
+
+			// Set the node to be of type None so that the node won't be built
+			cfg.Node.Type = nodeconfig.TypeNone
+
+			// Build the parsing context, without initializing the modules
+			parseCtx, err := parsecmdtypes.GetInspectionContext(cfg, parseConfig)
+			if err != nil {
+				return err
+			}
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, "MODULE\tHANDLERS\tMESSAGE TYPES\tTX EVENT TYPES")
+			for _, module := range parseCtx.Modules {
+				msgTypes, txEventTypes := "*", "*"
+				if filter, ok := module.(modules.MessageFilter); ok {
+					msgTypes = joinOrAll(filter.MessageTypes())
+					txEventTypes = joinOrAll(filter.TxEventTypes())
+				}
+
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", module.Name(), strings.Join(getHandlers(module), ","), msgTypes, txEventTypes)
+			}
+			err = writer.Flush()
+			if err != nil {
+				return err
+			}
+
+			// Print the message routes
+			router := parseCtx.MessageRouter
+			if len(router.MessageTypes()) > 0 {
+				fmt.Println()
+				writer = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(writer, "MESSAGE TYPE\tMODULES")
+				for _, msgType := range router.MessageTypes() {
+					fmt.Fprintf(writer, "%s\t%s\n", msgType, strings.Join(modules.Modules(router.Modules(msgType)).Names(), ","))
+				}
+				fmt.Fprintf(writer, "%s\t%s\n", "*", strings.Join(modules.Modules(router.Modules("")).Names(), ","))
+				return writer.Flush()
+			}
+
+			return nil
+		},
+	}
+}
+
+// getHandlers returns the names of the handlers implemented by the given module
+func getHandlers(module modules.Module) []string {
+	var handlers []string
+	if _, ok := module.(modules.GenesisModule); ok {
+		handlers = append(handlers, "genesis")
+	}
+	if _, ok := module.(modules.BlockModule); ok {
+		handlers = append(handlers, "block")
+	}
+	if _, ok := module.(modules.TransactionModule); ok {
+		handlers = append(handlers, "tx")
+	}
+	if _, ok := module.(modules.MessageModule); ok {
+		handlers = append(handlers, "msg")
+	}
+	if _, ok := module.(modules.AuthzMessageModule); ok {
+		handlers = append(handlers, "authz_msg")
+	}
+	if _, ok := module.(modules.PeriodicOperationsModule); ok {
+		handlers = append(handlers, "periodic")
+	}
+	if _, ok := module.(modules.AsyncOperationsModule); ok {
+		handlers = append(handlers, "async")
+	}
+	if len(handlers) == 0 {
+		handlers = append(handlers, "-")
+	}
+	return handlers
+}
+
+// joinOrAll joins the given values, returning "*" if there are none
+func joinOrAll(values []string) string {
+	if len(values) == 0 {
+		return "*"
+	}
+	return strings.Join(values, ",")
+}
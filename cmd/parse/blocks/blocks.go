@@ -36,6 +36,7 @@ will be replaced with the data downloaded from the node.
 			if err != nil {
 				return err
 			}
+			defer parsecmdtypes.StopModules(parseCtx)
 
 			workerCtx := parser.NewContext(parseCtx.Node, parseCtx.Database, parseCtx.Logger, parseCtx.Modules)
 			worker := parser.NewWorker(workerCtx, nil, 0)
@@ -28,6 +28,7 @@ func newMissingCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer parsecmdtypes.StopModules(parseCtx)
 
 			workerCtx := parser.NewContext(parseCtx.Node, parseCtx.Database, parseCtx.Logger, parseCtx.Modules)
 			worker := parser.NewWorker(workerCtx, nil, 0)
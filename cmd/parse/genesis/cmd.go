@@ -38,6 +38,7 @@ file itself and not the on-chain data.`,
 			if err != nil {
 				return err
 			}
+			defer parsecmdtypes.StopModules(parseCtx)
 
 			// Get the file path
 			genesisFilePath := cfg.Parser.GenesisFilePath
@@ -31,6 +31,7 @@ You can specify a custom height range by using the %s and %s flags.
 			if err != nil {
 				return err
 			}
+			defer parsecmdtypes.StopModules(parseCtx)
 
 			workerCtx := parser.NewContext(parseCtx.Node, parseCtx.Database, parseCtx.Logger, parseCtx.Modules)
 			worker := parser.NewWorker(workerCtx, nil, 0)
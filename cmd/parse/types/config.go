@@ -28,7 +28,26 @@ func ReadConfig(cfg *Config) (config.Config, error) {
 	}
 
 	// Read the config
-	return config.Read(file, cfg.GetConfigParser())
+	junoCfg, err := config.Read(file, cfg.GetConfigParser())
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	// Make sure the node configuration is valid
+	if junoCfg.Node.Details != nil {
+		err = junoCfg.Node.Details.Validate()
+		if err != nil {
+			return config.Config{}, fmt.Errorf("invalid node config: %s", err)
+		}
+	}
+
+	// Make sure the configuration of all the enabled modules is valid
+	err = junoCfg.ValidateModulesConfigs()
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	return junoCfg, nil
 }
 
 // UpdatedGlobalCfg parses the configuration file using the provided configuration and sets the
@@ -10,14 +10,29 @@ import (
 	"github.com/forbole/juno/v5/types/config"
 
 	"github.com/forbole/juno/v5/database"
+	"github.com/forbole/juno/v5/logging"
+	"github.com/forbole/juno/v5/modules"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	modsregistrar "github.com/forbole/juno/v5/modules/registrar"
 )
 
-// GetParserContext setups all the things that can be used to later parse the chain state
+// GetParserContext setups all the things that can be used to later parse the chain state, initializing all the modules.
+// Once done, the modules of the returned context should be stopped using StopModules.
 func GetParserContext(cfg config.Config, parseConfig *Config) (*parser.Context, error) {
+	return getParserContext(cfg, parseConfig, true)
+}
+
+// GetInspectionContext setups a parsing context whose modules are not initialized.
+// This should be used by the commands that only inspect the modules (eg. to list them) without parsing the chain state,
+// so that the modules initialization side effects (eg. connecting to external services) are avoided.
+func GetInspectionContext(cfg config.Config, parseConfig *Config) (*parser.Context, error) {
+	return getParserContext(cfg, parseConfig, false)
+}
+
+// getParserContext setups the parsing context, initializing the modules only if initModules is true
+func getParserContext(cfg config.Config, parseConfig *Config, initModules bool) (*parser.Context, error) {
 	// Setup the SDK configuration
 	sdkConfig, sealed := getConfig()
 	if !sealed {
@@ -51,13 +66,36 @@ func GetParserContext(cfg config.Config, parseConfig *Config) (*parser.Context,
 	}
 
 	// Get the modules
-	context := modsregistrar.NewContext(cfg, sdkConfig, db, cp, parseConfig.GetLogger())
+	context := modsregistrar.NewContext(cfg, sdkConfig, db, cp, parseConfig.GetLogger()).
+		WithCodec(parseConfig.GetCodec())
 	mods := parseConfig.GetRegistrar().BuildModules(context)
 	registeredModules := modsregistrar.GetModules(mods, cfg.Chain.Modules, parseConfig.GetLogger())
 
+	// Sort the modules based on their dependencies
+	registeredModules, err = modules.SortByDependencies(registeredModules)
+	if err != nil {
+		return nil, fmt.Errorf("error while resolving modules dependencies: %s", err)
+	}
+	parseConfig.GetLogger().Info("resolved modules order", "modules", modules.Modules(registeredModules).Names())
+
+	// Initialize the modules
+	if initModules {
+		err = modules.InitModules(registeredModules)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return parser.NewContext(cp, db, parseConfig.GetLogger(), registeredModules), nil
 }
 
+// StopModules stops all the modules of the given context, logging any error
+func StopModules(ctx *parser.Context) {
+	for moduleName, err := range modules.StopModules(ctx.Modules) {
+		ctx.Logger.Error("error while stopping module", "err", err, logging.LogKeyModule, moduleName)
+	}
+}
+
 // getConfig returns the SDK Config instance as well as if it's sealed or not
 func getConfig() (config *sdk.Config, sealed bool) {
 	sdkConfig := sdk.GetConfig()
@@ -1,6 +1,10 @@
 package types
 
 import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+
 	"github.com/forbole/juno/v5/logging"
 	"github.com/forbole/juno/v5/types/config"
 
@@ -11,6 +15,7 @@ import (
 
 // Config contains all the configuration for the "parse" command
 type Config struct {
+	codec        codec.Codec
 	registrar    registrar.Registrar
 	configParser config.Parser
 	setupCfg     SdkConfigSetup
@@ -23,6 +28,23 @@ func NewConfig() *Config {
 	return &Config{}
 }
 
+// WithCodec sets the codec to be used to decode the chain messages.
+// It should contain all the interfaces registered by the chain modules.
+func (cfg *Config) WithCodec(cdc codec.Codec) *Config {
+	cfg.codec = cdc
+	return cfg
+}
+
+// GetCodec returns the codec to be used to decode the chain messages
+func (cfg *Config) GetCodec() codec.Codec {
+	if cfg.codec == nil {
+		registry := codectypes.NewInterfaceRegistry()
+		std.RegisterInterfaces(registry)
+		return codec.NewProtoCodec(registry)
+	}
+	return cfg.codec
+}
+
 // WithRegistrar sets the modules registrar to be used
 func (cfg *Config) WithRegistrar(r registrar.Registrar) *Config {
 	cfg.registrar = r
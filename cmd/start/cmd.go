@@ -1,6 +1,7 @@
 package start
 
 import (
+	"fmt"
 	"os"
 	"os/signal"
 	"sync"
@@ -9,6 +10,7 @@ import (
 
 	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
 	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/node/local"
 	"github.com/forbole/juno/v5/types/utils"
 
 	"github.com/forbole/juno/v5/logging"
@@ -34,26 +36,62 @@ func NewStartCmd(cmdCfg *parsecmdtypes.Config) *cobra.Command {
 		Short:   "Start parsing the blockchain data",
 		PreRunE: parsecmdtypes.ReadConfigPreRunE(cmdCfg),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			err := checkNodeConfig(config.Cfg)
+			if err != nil {
+				return err
+			}
+
 			context, err := parsecmdtypes.GetParserContext(config.Cfg, cmdCfg)
 			if err != nil {
 				return err
 			}
 
-			// Run all the additional operations
-			for _, module := range context.Modules {
-				if module, ok := module.(modules.AdditionalOperationsModule); ok {
-					err = module.RunAdditionalOperations()
-					if err != nil {
-						return err
-					}
-				}
+			err = startModules(context)
+			if err == nil {
+				err = startParsing(context)
 			}
 
-			return startParsing(context)
+			if err != nil {
+				// Make sure the modules release their resources (eg. exporting the pending spans) before exiting
+				parsecmdtypes.StopModules(context)
+				return err
+			}
+
+			return nil
 		},
 	}
 }
 
+// checkNodeConfig returns an error if the node described by the given config cannot be used to parse the chain
+// as requested by the same config
+func checkNodeConfig(cfg config.Config) error {
+	details, ok := cfg.Node.Details.(*local.Details)
+	if ok && details.ReadOnly && cfg.Parser.ParseNewBlocks {
+		// Read-only databases (and their snapshots) never see the blocks written by the node after being opened
+		return fmt.Errorf("listen_new_blocks cannot be enabled when using a local node in read-only mode")
+	}
+	return nil
+}
+
+// startModules runs the additional operations of all the modules, and then starts them
+func startModules(ctx *parser.Context) error {
+	// Run all the additional operations
+	for _, module := range ctx.Modules {
+		if module, ok := module.(modules.AdditionalOperationsModule); ok {
+			err := module.RunAdditionalOperations()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Provide the modules with the database, the node and the parsing status
+	ctx.InjectModulesDependencies()
+
+	// Start all the modules
+	return modules.StartModules(ctx.Modules)
+}
+
 // startParsing represents the function that should be called when the parse command is executed
 func startParsing(ctx *parser.Context) error {
 	// Get the config
@@ -74,6 +112,7 @@ func startParsing(ctx *parser.Context) error {
 
 	// Create a queue that will collect, aggregate, and export blocks and metadata
 	exportQueue := types.NewQueue(25)
+	ctx.ParsingStatus.SetQueue(exportQueue)
 
 	// Create workers
 	workers := make([]parser.Worker, cfg.Workers)
@@ -141,6 +180,22 @@ func enqueueMissingBlocks(exportQueue types.HeightQueue, ctx *parser.Context) {
 		startHeight = utils.MaxInt64(1, lastDbBlockHeight)
 	}
 
+	// Skip the heights whose blocks have been pruned, so that they are not parsed and pruned again
+	for _, module := range ctx.Modules {
+		if pruningModule, ok := module.(modules.BlocksPruningModule); ok {
+			prunedHeight, err := pruningModule.GetBlocksPrunedHeight()
+			if err != nil {
+				ctx.Logger.Error("failed to get blocks pruned height", "error", err, logging.LogKeyModule, module.Name())
+				continue
+			}
+
+			if prunedHeight > startHeight {
+				ctx.Logger.Info("skipping pruned blocks", "pruned_height", prunedHeight, logging.LogKeyModule, module.Name())
+				startHeight = prunedHeight
+			}
+		}
+	}
+
 	if cfg.FastSync {
 		ctx.Logger.Info("fast sync is enabled, ignoring all previous blocks", "latest_block_height", latestBlockHeight)
 		for _, module := range ctx.Modules {
@@ -171,6 +226,7 @@ func enqueueNewBlocks(exportQueue types.HeightQueue, ctx *parser.Context) {
 	// Enqueue upcoming heights
 	for {
 		latestBlockHeight := mustGetLatestHeight(ctx)
+		updateChainHeadLag(ctx, latestBlockHeight)
 
 		// Enqueue all heights from the current height up to the latest height
 		for ; currHeight <= latestBlockHeight; currHeight++ {
@@ -181,6 +237,18 @@ func enqueueNewBlocks(exportQueue types.HeightQueue, ctx *parser.Context) {
 	}
 }
 
+// updateChainHeadLag updates the Prometheus metric tracking how many heights the database is behind the given
+// chain head height
+func updateChainHeadLag(ctx *parser.Context, latestBlockHeight int64) {
+	lastDbBlockHeight, err := ctx.Database.GetLastBlockHeight()
+	if err != nil {
+		ctx.Logger.Error("failed to get last block height from database", "error", err)
+		return
+	}
+
+	logging.ChainHeadLag.Set(float64(latestBlockHeight - lastDbBlockHeight))
+}
+
 // mustGetLatestHeight tries getting the latest height from the RPC client.
 // If after 50 tries no latest height can be found, it returns 0.
 func mustGetLatestHeight(ctx *parser.Context) int64 {
@@ -212,6 +280,10 @@ func trapSignal(ctx *parser.Context) {
 	go func() {
 		sig := <-sigCh
 		ctx.Logger.Info("caught signal; shutting down...", "signal", sig.String())
+
+		// Stop all the modules
+		parsecmdtypes.StopModules(ctx)
+
 		defer ctx.Node.Stop()
 		defer ctx.Database.Close()
 		defer waitGroup.Done()
@@ -1,6 +1,9 @@
 package database
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/forbole/juno/v5/logging"
 
 	databaseconfig "github.com/forbole/juno/v5/database/config"
@@ -66,6 +69,139 @@ type PruningDb interface {
 	GetLastPruned() (int64, error)
 }
 
+// RetentionPruningDb represents a database that supports pruning each table based on its retention policy
+type RetentionPruningDb interface {
+	PruningDb
+
+	// PruneTable deletes the rows of the given table having a height within [fromHeight, toHeight).
+	// The rows having a height that is a multiple of any of the keepEvery values are kept.
+	// An error is returned if the table cannot be pruned or if the operation fails.
+	PruneTable(table string, fromHeight, toHeight int64, keepEvery []int64) error
+
+	// DropPrunedPartitions drops all the partitions of the given table that only contain heights
+	// lower than the given one, returning the names of the dropped partitions.
+	// If any keepEvery value is given, the rows having a height that is a multiple of any of them are
+	// moved into a new partition with the same id before dropping the old one.
+	// If the table is not partitioned, nothing is dropped.
+	// An error is returned if the operation fails.
+	DropPrunedPartitions(table string, height int64, keepEvery []int64) ([]string, error)
+
+	// GetLowestHeight returns the lowest height stored inside the given table, or 0 if the table is empty.
+	// An error is returned if the operation fails.
+	GetLowestHeight(table string) (int64, error)
+
+	// GetLastHeightBefore returns the highest height of the blocks having a timestamp before the given one,
+	// or 0 if no such block exists.
+	// An error is returned if the operation fails.
+	GetLastHeightBefore(timestamp time.Time) (int64, error)
+
+	// GetLastBlockHeightAndTime returns the height and the timestamp of the last block stored inside the database,
+	// or 0 and the zero time if no block is stored.
+	// An error is returned if the operation fails.
+	GetLastBlockHeightAndTime() (int64, time.Time, error)
+}
+
+// ModuleStoreDb represents a database that allows modules to store their own state as versioned JSON values
+type ModuleStoreDb interface {
+	// SaveModuleState stores the given value for the given key inside the given namespace, as it was at the given height.
+	// If a value has already been stored for the same key at the same height, it is replaced.
+	// An error is returned if the operation fails.
+	SaveModuleState(namespace, key string, height int64, value json.RawMessage) error
+
+	// GetModuleState returns the value stored for the given key inside the given namespace at the highest height that
+	// is less or equal to the given one, along with such height.
+	// If no value is found, nil is returned instead.
+	// An error is returned if the operation fails.
+	GetModuleState(namespace, key string, height int64) (value json.RawMessage, valueHeight int64, err error)
+
+	// DeleteModuleState deletes all the values stored for the given key inside the given namespace.
+	// An error is returned if the operation fails.
+	DeleteModuleState(namespace, key string) error
+
+	// PruneModuleStates deletes all the values stored at a height lower than the given one, except for the value
+	// of each key at such height. This way, the state of the modules at any height greater or equal
+	// to the given one is preserved.
+	// An error is returned if the operation fails.
+	PruneModuleStates(height int64) error
+}
+
+// RawAddressesMessageDb represents a database that stores the addresses involved by each message both in their
+// canonical form and in the raw form they have inside the message itself
+type RawAddressesMessageDb interface {
+	// SaveMessageWithRawAddresses stores a single message, along with the canonical and raw forms of the
+	// addresses it involves.
+	// An error is returned if the operation fails.
+	SaveMessageWithRawAddresses(height int64, txHash string, msg types.Message, addresses []string, rawAddresses []string) error
+}
+
+// AddressMessageDb represents a database that indexes the messages by the addresses they involve
+type AddressMessageDb interface {
+	// SaveAddressMessages stores the given addresses involved by the message having the given index inside the
+	// transaction having the given hash, along with their roles.
+	// An error is returned if the operation fails.
+	SaveAddressMessages(height int64, txHash string, msgIndex int, addresses []types.MessageAddress) error
+
+	// GetAddressMessages returns the messages involving the given address, ordered from the most recent one.
+	// If roles is not empty, only the messages in which the address has one of the given roles are returned.
+	// An error is returned if the operation fails.
+	GetAddressMessages(address string, roles []types.AddressRole, limit, offset int64) ([]types.AddressMessage, error)
+}
+
+// PartitionedTables contains the tables that are partitioned by height when a partition size is set
+var PartitionedTables = []string{"transaction", "message", "address_message"}
+
+// IsPartitionedTable tells whether the given table is partitioned by height when a partition size is set
+func IsPartitionedTable(table string) bool {
+	for _, t := range PartitionedTables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
+
+// PartitionsDb represents a database that stores some of its tables split into partitions, each one containing
+// the rows of a range of heights
+type PartitionsDb interface {
+	// GetPartitions returns all the partitions of the given table, ordered by their id.
+	// An error is returned if the table is not partitioned or if the operation fails.
+	GetPartitions(table string) ([]Partition, error)
+
+	// CreatePartitionIfNotExists creates the partition of the given table having the given id, if not existing.
+	// An error is returned if the operation fails.
+	CreatePartitionIfNotExists(table string, partitionID int64) error
+
+	// DropPartition detaches the partition of the given table having the given id and drops it,
+	// deleting all the rows it contains.
+	// An error is returned if the operation fails.
+	DropPartition(table string, partitionID int64) error
+}
+
+// Partition contains the details of a single partition of a table
+type Partition struct {
+	Table string
+	Name  string
+	ID    int64
+	Rows  int64
+	Size  int64
+}
+
+// NewPartition allows to build a new Partition instance
+func NewPartition(table, name string, id int64, rows, size int64) Partition {
+	return Partition{
+		Table: table,
+		Name:  name,
+		ID:    id,
+		Rows:  rows,
+		Size:  size,
+	}
+}
+
+// Heights returns the range of heights [from, to) contained inside the partition, given the size of the partitions
+func (p Partition) Heights(partitionSize int64) (from, to int64) {
+	return p.ID * partitionSize, (p.ID + 1) * partitionSize
+}
+
 // Context contains the data that might be used to build a Database instance
 type Context struct {
 	Cfg    databaseconfig.Config
@@ -0,0 +1,201 @@
+package v6
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/juno/v5/types"
+)
+
+// Migrate implements database.Migrator.
+// All the statements can be run more than once, so that a migration that has been interrupted can be run again.
+func (db *Migrator) Migrate() error {
+	log.Info().Msg("creating the module_state table")
+	err := db.createModuleStateTable()
+	if err != nil {
+		return fmt.Errorf("error while creating module_state table: %s", err)
+	}
+
+	log.Info().Msg("adding the raw involved addresses to the message table")
+	err = db.addRawInvolvedAddressesColumn()
+	if err != nil {
+		return fmt.Errorf("error while altering message table: %s", err)
+	}
+
+	err = db.migrateMessagesByAddressFunction()
+	if err != nil {
+		return fmt.Errorf("error while migrating the messages_by_address function: %s", err)
+	}
+
+	log.Info().Msg("creating the address_message table")
+	err = db.createAddressMessageTable()
+	if err != nil {
+		return fmt.Errorf("error while creating address_message table: %s", err)
+	}
+
+	err = db.migrateAddressMessages()
+	if err != nil {
+		return fmt.Errorf("error while indexing existing messages by address: %s", err)
+	}
+
+	log.Info().Msg("adding the decoded values to the message table")
+	err = db.addDecodedValueColumns()
+	if err != nil {
+		return fmt.Errorf("error while altering message table: %s", err)
+	}
+
+	return nil
+}
+
+// createModuleStateTable creates the table used by the modules to store their own state
+func (db *Migrator) createModuleStateTable() error {
+	stmt := `
+CREATE TABLE IF NOT EXISTS module_state
+(
+    namespace TEXT   NOT NULL,
+    key       TEXT   NOT NULL,
+    height    BIGINT NOT NULL,
+    value     JSONB  NOT NULL,
+    PRIMARY KEY (namespace, key, height)
+);
+CREATE INDEX IF NOT EXISTS module_state_height_index ON module_state (height);`
+
+	_, err := db.SQL.Exec(stmt)
+	return err
+}
+
+// addRawInvolvedAddressesColumn adds the column containing the addresses involved by each message as they appear
+// inside the message itself, before being converted to their canonical form
+func (db *Migrator) addRawInvolvedAddressesColumn() error {
+	stmt := `
+ALTER TABLE message ADD COLUMN IF NOT EXISTS raw_involved_accounts_addresses TEXT[] NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS message_raw_involved_accounts_index ON message USING GIN(raw_involved_accounts_addresses);`
+
+	_, err := db.SQL.Exec(stmt)
+	return err
+}
+
+// migrateMessagesByAddressFunction migrates the messages_by_address function so that it also matches
+// the raw addresses involved by each message
+func (db *Migrator) migrateMessagesByAddressFunction() error {
+	stmt := `
+CREATE OR REPLACE FUNCTION messages_by_address(
+    addresses TEXT[],
+    types TEXT[],
+    "limit" BIGINT = 100,
+    "offset" BIGINT = 0)
+    RETURNS SETOF message AS
+$$
+SELECT * FROM message
+WHERE (cardinality(types) = 0 OR type = ANY (types))
+  AND (addresses && involved_accounts_addresses OR addresses && raw_involved_accounts_addresses)
+ORDER BY height DESC LIMIT "limit" OFFSET "offset"
+$$ LANGUAGE sql STABLE;`
+
+	_, err := db.SQL.Exec(stmt)
+	return err
+}
+
+// addDecodedValueColumns adds the columns containing the value of each message decoded into its concrete type,
+// along with the error returned while decoding it
+func (db *Migrator) addDecodedValueColumns() error {
+	stmt := `
+ALTER TABLE message ADD COLUMN IF NOT EXISTS decoded_value JSONB;
+ALTER TABLE message ADD COLUMN IF NOT EXISTS decode_error TEXT;`
+
+	_, err := db.SQL.Exec(stmt)
+	return err
+}
+
+// createAddressMessageTable creates the table indexing the messages by the addresses they involve
+func (db *Migrator) createAddressMessageTable() error {
+	stmt := `
+CREATE TABLE IF NOT EXISTS address_message
+(
+    address      TEXT   NOT NULL,
+    tx_hash      TEXT   NOT NULL,
+    msg_index    BIGINT NOT NULL,
+    role         TEXT   NOT NULL,
+
+    /* PSQL partition */
+    partition_id BIGINT NOT NULL DEFAULT 0,
+    height       BIGINT NOT NULL,
+    CONSTRAINT unique_address_message UNIQUE (address, tx_hash, msg_index, role, partition_id)
+) PARTITION BY LIST (partition_id);
+CREATE INDEX IF NOT EXISTS address_message_address_height_index ON address_message (address, height DESC);
+CREATE INDEX IF NOT EXISTS address_message_tx_hash_index ON address_message (tx_hash);
+CREATE INDEX IF NOT EXISTS address_message_height_index ON address_message (height);`
+
+	_, err := db.SQL.Exec(stmt)
+	return err
+}
+
+// migrateAddressMessages creates an address_message partition for each existing message partition, and fills it
+// with the involved addresses of the messages it contains. Since the role of such addresses is not known,
+// they are all stored using the types.AddressRoleInvolved role.
+func (db *Migrator) migrateAddressMessages() error {
+	partitionIDs, err := db.getPartitionIDs("message")
+	if err != nil {
+		return fmt.Errorf("error while getting message partitions: %s", err)
+	}
+
+	for _, partitionID := range partitionIDs {
+		log.Debug().Int64("partition", partitionID).Msg("indexing messages by address")
+
+		err = db.createPartitionTable("address_message", partitionID)
+		if err != nil {
+			return fmt.Errorf("error while creating address_message partition table: %s", err)
+		}
+
+		stmt := fmt.Sprintf(`
+INSERT INTO address_message (address, tx_hash, msg_index, role, partition_id, height)
+SELECT DISTINCT unnest(involved_accounts_addresses), transaction_hash, index, $1, partition_id, height 
+FROM message_%d 
+ON CONFLICT DO NOTHING`, partitionID)
+
+		_, err = db.SQL.Exec(stmt, types.AddressRoleInvolved)
+		if err != nil {
+			return fmt.Errorf("error while filling address_message partition %d: %s", partitionID, err)
+		}
+	}
+
+	return nil
+}
+
+// getPartitionIDs returns the ids of the partitions of the given table that have been created by Juno
+func (db *Migrator) getPartitionIDs(table string) ([]int64, error) {
+	stmt := `
+SELECT child.relname FROM pg_inherits 
+JOIN pg_class parent ON pg_inherits.inhparent = parent.oid 
+JOIN pg_class child ON pg_inherits.inhrelid = child.oid 
+WHERE parent.relname = $1`
+
+	var names []string
+	err := db.SQL.Select(&names, stmt, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var partitionIDs []int64
+	for _, name := range names {
+		partitionID, err := strconv.ParseInt(strings.TrimPrefix(name, table+"_"), 10, 64)
+		if err != nil {
+			// Not a partition created by Juno
+			continue
+		}
+		partitionIDs = append(partitionIDs, partitionID)
+	}
+
+	return partitionIDs, nil
+}
+
+func (db *Migrator) createPartitionTable(table string, partitionID int64) error {
+	partitionTable := fmt.Sprintf("%s_%v", table, partitionID)
+
+	stmt := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES IN (%v)`, partitionTable, table, partitionID)
+	_, err := db.SQL.Exec(stmt)
+	return err
+}
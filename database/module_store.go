@@ -0,0 +1,99 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// ModuleStore allows modules to store their own state (eg. the last processed proposal) as JSON values
+// versioned by height, without having to create their own tables.
+// Each module should use its own namespace, obtained by calling Namespace.
+type ModuleStore struct {
+	db        Database
+	namespace string
+}
+
+// NewModuleStore returns a new ModuleStore backed by the given database.
+// The returned store has no namespace, so Namespace should be called before using it.
+func NewModuleStore(db Database) *ModuleStore {
+	return &ModuleStore{
+		db: db,
+	}
+}
+
+// Namespace returns a new ModuleStore whose keys are all stored inside the given namespace
+func (s *ModuleStore) Namespace(namespace string) *ModuleStore {
+	return &ModuleStore{
+		db:        s.db,
+		namespace: namespace,
+	}
+}
+
+// getDb returns the database as a ModuleStoreDb, or an error if it does not support storing modules states
+func (s *ModuleStore) getDb() (ModuleStoreDb, error) {
+	if s.namespace == "" {
+		return nil, fmt.Errorf("module store namespace not set")
+	}
+
+	storeDb, ok := s.db.(ModuleStoreDb)
+	if !ok {
+		return nil, fmt.Errorf("module store is used, but your database does not implement ModuleStoreDb")
+	}
+	return storeDb, nil
+}
+
+// Set stores the JSON encoding of the given value for the given key, as it was at the given height
+func (s *ModuleStore) Set(key string, height int64, value interface{}) error {
+	storeDb, err := s.getDb()
+	if err != nil {
+		return err
+	}
+
+	bz, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error while serializing %s value: %s", key, err)
+	}
+
+	return storeDb.SaveModuleState(s.namespace, key, height, bz)
+}
+
+// Get reads the latest value stored for the given key into value, returning false if no value has been found
+func (s *ModuleStore) Get(key string, value interface{}) (found bool, err error) {
+	return s.GetAt(key, math.MaxInt64, value)
+}
+
+// GetAt reads the value that the given key had at the given height into value,
+// returning false if no value has been found
+func (s *ModuleStore) GetAt(key string, height int64, value interface{}) (found bool, err error) {
+	storeDb, err := s.getDb()
+	if err != nil {
+		return false, err
+	}
+
+	bz, _, err := storeDb.GetModuleState(s.namespace, key, height)
+	if err != nil {
+		return false, fmt.Errorf("error while getting %s value: %s", key, err)
+	}
+
+	if bz == nil {
+		return false, nil
+	}
+
+	err = json.Unmarshal(bz, value)
+	if err != nil {
+		return false, fmt.Errorf("error while deserializing %s value: %s", key, err)
+	}
+
+	return true, nil
+}
+
+// Delete deletes all the values stored for the given key
+func (s *ModuleStore) Delete(key string) error {
+	storeDb, err := s.getDb()
+	if err != nil {
+		return err
+	}
+
+	return storeDb.DeleteModuleState(s.namespace, key)
+}
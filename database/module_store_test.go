@@ -0,0 +1,59 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/database"
+	"github.com/forbole/juno/v5/database/testutils"
+)
+
+func TestModuleStore(t *testing.T) {
+	store := database.NewModuleStore(testutils.NewModuleStoreDb())
+	gov := store.Namespace("gov")
+
+	type cursor struct {
+		ProposalID uint64 `json:"proposal_id"`
+	}
+
+	require.NoError(t, gov.Set("last_proposal", 10, cursor{ProposalID: 1}))
+	require.NoError(t, gov.Set("last_proposal", 20, cursor{ProposalID: 2}))
+
+	// The latest value should be returned
+	var value cursor
+	found, err := gov.Get("last_proposal", &value)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, uint64(2), value.ProposalID)
+
+	// The value at a given height should be returned
+	found, err = gov.GetAt("last_proposal", 15, &value)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, uint64(1), value.ProposalID)
+
+	found, err = gov.GetAt("last_proposal", 5, &value)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Values should be scoped by namespace
+	found, err = store.Namespace("staking").Get("last_proposal", &value)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Deleted values should not be found
+	require.NoError(t, gov.Delete("last_proposal"))
+	found, err = gov.Get("last_proposal", &value)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// A store without namespace should not be usable
+	require.Error(t, store.Set("key", 1, "value"))
+}
+
+func TestModuleStore_UnsupportedDatabase(t *testing.T) {
+	store := database.NewModuleStore(nil).Namespace("gov")
+	_, err := store.Get("last_proposal", new(int))
+	require.Error(t, err)
+}
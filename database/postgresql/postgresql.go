@@ -5,7 +5,10 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
@@ -52,7 +55,15 @@ func Builder(ctx *database.Context) (database.Database, error) {
 }
 
 // type check to ensure interface is properly implemented
-var _ database.Database = &Database{}
+var (
+	_ database.Database              = &Database{}
+	_ database.PruningDb             = &Database{}
+	_ database.RetentionPruningDb    = &Database{}
+	_ database.ModuleStoreDb         = &Database{}
+	_ database.RawAddressesMessageDb = &Database{}
+	_ database.AddressMessageDb      = &Database{}
+	_ database.PartitionsDb          = &Database{}
+)
 
 // Database defines a wrapper around a SQL database and implements functionality
 // for data aggregation and exporting.
@@ -61,22 +72,129 @@ type Database struct {
 	Logger logging.Logger
 }
 
-// CreatePartitionIfNotExists creates a new partition having the given partition id if not existing
+// referencingPartitions contains, for each partitioned table, the partitioned tables whose rows reference its rows.
+// Since both tables are partitioned in the same way, the rows of a partition can only be referenced by the rows
+// of the partitions having the same id.
+var referencingPartitions = map[string][]string{
+	"transaction": {"message"},
+}
+
+// checkPartitionedTable returns an error if the given table is not partitioned
+func checkPartitionedTable(table string) error {
+	if !database.IsPartitionedTable(table) {
+		return fmt.Errorf("table %s is not partitioned", table)
+	}
+	return nil
+}
+
+// getPartitionName returns the name of the partition of the given table having the given id
+func getPartitionName(table string, partitionID int64) string {
+	return fmt.Sprintf("%s_%d", table, partitionID)
+}
+
+// CreatePartitionIfNotExists implements database.PartitionsDb
 func (db *Database) CreatePartitionIfNotExists(table string, partitionID int64) error {
-	partitionTable := fmt.Sprintf("%s_%d", table, partitionID)
+	err := checkPartitionedTable(table)
+	if err != nil {
+		return err
+	}
 
 	stmt := fmt.Sprintf(
 		"CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES IN (%d)",
-		partitionTable,
+		getPartitionName(table, partitionID),
 		table,
 		partitionID,
 	)
-	_, err := db.SQL.Exec(stmt)
+	_, err = db.SQL.Exec(stmt)
+	return err
+}
+
+// GetPartitions implements database.PartitionsDb
+func (db *Database) GetPartitions(table string) ([]database.Partition, error) {
+	partitions, err := db.getPartitions(table)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, partition := range partitions {
+		err = db.SQL.QueryRow(fmt.Sprintf(`SELECT COUNT(*) FROM %s`, partition.Name)).Scan(&partitions[i].Rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while counting rows of partition %s: %s", partition.Name, err)
+		}
+	}
+
+	return partitions, nil
+}
+
+// getPartitions returns the partitions of the given table, ordered by their id, without counting their rows
+func (db *Database) getPartitions(table string) ([]database.Partition, error) {
+	err := checkPartitionedTable(table)
+	if err != nil {
+		return nil, err
+	}
 
+	stmt := `
+SELECT child.relname AS name, pg_total_relation_size(child.oid) AS size FROM pg_inherits 
+JOIN pg_class parent ON pg_inherits.inhparent = parent.oid 
+JOIN pg_class child ON pg_inherits.inhrelid = child.oid 
+WHERE parent.relname = $1`
+
+	var rows []struct {
+		Name string `db:"name"`
+		Size int64  `db:"size"`
+	}
+	err = db.SQL.Select(&rows, stmt, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var partitions []database.Partition
+	for _, row := range rows {
+		partitionID, err := strconv.ParseInt(strings.TrimPrefix(row.Name, table+"_"), 10, 64)
+		if err != nil {
+			// Not a partition created by Juno
+			continue
+		}
+
+		partitions = append(partitions, database.NewPartition(table, row.Name, partitionID, 0, row.Size))
+	}
+
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].ID < partitions[j].ID
+	})
+
+	return partitions, nil
+}
+
+// DropPartition implements database.PartitionsDb
+func (db *Database) DropPartition(table string, partitionID int64) error {
+	err := checkPartitionedTable(table)
 	if err != nil {
 		return err
 	}
 
+	partition := getPartitionName(table, partitionID)
+
+	referenced, err := db.isPartitionReferenced(table, partitionID)
+	if err != nil {
+		return err
+	}
+	if referenced {
+		return fmt.Errorf("partition %s is still referenced, drop the partitions of the tables referencing %s first",
+			partition, table)
+	}
+
+	// Detach the partition first so that the parent table is only locked for a short time
+	_, err = db.SQL.Exec(fmt.Sprintf(`ALTER TABLE %s DETACH PARTITION %s`, table, partition))
+	if err != nil {
+		return fmt.Errorf("error while detaching partition %s: %s", partition, err)
+	}
+
+	_, err = db.SQL.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s`, partition))
+	if err != nil {
+		return fmt.Errorf("error while dropping partition %s: %s", partition, err)
+	}
+
 	return nil
 }
 
@@ -276,6 +394,13 @@ func (db *Database) SaveCommitSignatures(signatures []*types.CommitSig) error {
 
 // SaveMessage implements database.Database
 func (db *Database) SaveMessage(height int64, txHash string, msg types.Message, addresses []string) error {
+	return db.SaveMessageWithRawAddresses(height, txHash, msg, addresses, []string{})
+}
+
+// SaveMessageWithRawAddresses implements database.RawAddressesMessageDb
+func (db *Database) SaveMessageWithRawAddresses(
+	height int64, txHash string, msg types.Message, addresses []string, rawAddresses []string,
+) error {
 	var partitionID int64
 	partitionSize := config.Cfg.Database.PartitionSize
 	if partitionSize > 0 {
@@ -286,24 +411,105 @@ func (db *Database) SaveMessage(height int64, txHash string, msg types.Message,
 		}
 	}
 
-	return db.saveMessageInsidePartition(height, txHash, addresses, msg, partitionID)
+	return db.saveMessageInsidePartition(height, txHash, addresses, rawAddresses, msg, partitionID)
 }
 
-// saveMessageInsidePartition stores the given message inside the partition having the provided id
-func (db *Database) saveMessageInsidePartition(height int64, txHash string, addresses []string, msg types.Message, partitionID int64) error {
+// saveMessageInsidePartition stores the given message inside the partition having the provided id.
+// If the message is a *types.DecodedMessage, its decoded value and decoding error are stored as well.
+func (db *Database) saveMessageInsidePartition(
+	height int64, txHash string, addresses []string, rawAddresses []string, msg types.Message, partitionID int64,
+) error {
 	stmt := `
-INSERT INTO message(transaction_hash, index, type, value, involved_accounts_addresses, height, partition_id) 
-VALUES ($1, $2, $3, $4, $5, $6, $7) 
+INSERT INTO message(transaction_hash, index, type, value, involved_accounts_addresses, raw_involved_accounts_addresses, decoded_value, decode_error, height, partition_id) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) 
 ON CONFLICT (transaction_hash, index, partition_id) DO UPDATE 
 	SET height = excluded.height, 
 		type = excluded.type,
 		value = excluded.value,
-		involved_accounts_addresses = excluded.involved_accounts_addresses`
+		involved_accounts_addresses = excluded.involved_accounts_addresses,
+		raw_involved_accounts_addresses = excluded.raw_involved_accounts_addresses,
+		decoded_value = excluded.decoded_value,
+		decode_error = excluded.decode_error`
+
+	var decodedValue, decodeError sql.NullString
+	if decodedMsg, ok := msg.(*types.DecodedMessage); ok {
+		decodedValue = sql.NullString{String: string(decodedMsg.Value), Valid: decodedMsg.Value != nil}
+		decodeError = sql.NullString{String: decodedMsg.DecodeError, Valid: decodedMsg.DecodeError != ""}
+	}
 
-	_, err := db.SQL.Exec(stmt, txHash, msg.GetIndex(), msg.GetType(), msg.GetBytes(), pq.Array(addresses), height, partitionID)
+	_, err := db.SQL.Exec(stmt,
+		txHash, msg.GetIndex(), msg.GetType(), msg.GetBytes(), pq.Array(addresses), pq.Array(rawAddresses),
+		decodedValue, decodeError, height, partitionID,
+	)
 	return err
 }
 
+// SaveAddressMessages implements database.AddressMessageDb
+func (db *Database) SaveAddressMessages(height int64, txHash string, msgIndex int, addresses []types.MessageAddress) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	var partitionID int64
+	partitionSize := config.Cfg.Database.PartitionSize
+	if partitionSize > 0 {
+		partitionID = height / partitionSize
+		err := db.CreatePartitionIfNotExists("address_message", partitionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	stmt := `INSERT INTO address_message (address, height, tx_hash, msg_index, role, partition_id) VALUES `
+
+	var params []interface{}
+	for i, address := range addresses {
+		ai := i * 6
+		stmt += fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d),", ai+1, ai+2, ai+3, ai+4, ai+5, ai+6)
+		params = append(params, address.Address, height, txHash, msgIndex, address.Role, partitionID)
+	}
+
+	stmt = stmt[:len(stmt)-1]
+	stmt += " ON CONFLICT DO NOTHING"
+
+	_, err := db.SQL.Exec(stmt, params...)
+	return err
+}
+
+// GetAddressMessages implements database.AddressMessageDb
+func (db *Database) GetAddressMessages(
+	address string, roles []types.AddressRole, limit, offset int64,
+) ([]types.AddressMessage, error) {
+	stmt := `
+SELECT address, height, tx_hash, msg_index, role FROM address_message 
+WHERE address = $1 AND (cardinality($2::TEXT[]) = 0 OR role = ANY($2)) 
+ORDER BY height DESC, msg_index DESC 
+LIMIT $3 OFFSET $4`
+
+	rolesStrings := make([]string, len(roles))
+	for i, role := range roles {
+		rolesStrings[i] = string(role)
+	}
+
+	rows, err := db.SQL.Query(stmt, address, pq.Array(rolesStrings), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []types.AddressMessage
+	for rows.Next() {
+		var msg types.AddressMessage
+		err = rows.Scan(&msg.Address, &msg.Height, &msg.TxHash, &msg.MsgIndex, &msg.Role)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
+
 // Close implements database.Database
 func (db *Database) Close() {
 	err := db.SQL.Close()
@@ -344,5 +550,295 @@ DELETE FROM message
 USING transaction 
 WHERE message.transaction_hash = transaction.hash AND transaction.height = $1
 `, height)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.SQL.Exec(`DELETE FROM address_message WHERE height = $1`, height)
+	return err
+}
+
+// prunableTables contains the tables that can be pruned using PruneTable
+var prunableTables = map[string]bool{
+	"block":           true,
+	"pre_commit":      true,
+	"transaction":     true,
+	"message":         true,
+	"address_message": true,
+}
+
+// checkPrunableTable returns an error if the given table cannot be pruned
+func checkPrunableTable(table string) error {
+	if !prunableTables[table] {
+		return fmt.Errorf("table %s cannot be pruned", table)
+	}
+	return nil
+}
+
+// PruneTable implements database.RetentionPruningDb
+func (db *Database) PruneTable(table string, fromHeight, toHeight int64, keepEvery []int64) error {
+	err := checkPrunableTable(table)
+	if err != nil {
+		return err
+	}
+
+	if len(keepEvery) > 0 {
+		stmt := fmt.Sprintf(`
+DELETE FROM %s WHERE height >= $1 AND height < $2 
+AND NOT EXISTS (SELECT 1 FROM unnest($3::BIGINT[]) AS keep_every WHERE height %% keep_every = 0)`, table)
+		_, err = db.SQL.Exec(stmt, fromHeight, toHeight, pq.Array(keepEvery))
+		return err
+	}
+
+	stmt := fmt.Sprintf(`DELETE FROM %s WHERE height >= $1 AND height < $2`, table)
+	_, err = db.SQL.Exec(stmt, fromHeight, toHeight)
+	return err
+}
+
+// DropPrunedPartitions implements database.RetentionPruningDb
+func (db *Database) DropPrunedPartitions(table string, height int64, keepEvery []int64) ([]string, error) {
+	err := checkPrunableTable(table)
+	if err != nil {
+		return nil, err
+	}
+
+	partitionSize := config.Cfg.Database.PartitionSize
+	if partitionSize <= 0 || !database.IsPartitionedTable(table) {
+		return nil, nil
+	}
+
+	partitions, err := db.getPartitions(table)
+	if err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	for _, partition := range partitions {
+		// Only drop the partitions whose heights are all lower than the given one
+		_, to := partition.Heights(partitionSize)
+		if to > height {
+			continue
+		}
+
+		if len(keepEvery) > 0 {
+			rebuilt, err := db.rebuildPrunedPartition(table, partition.ID, keepEvery)
+			if err != nil {
+				return dropped, err
+			}
+			if rebuilt {
+				dropped = append(dropped, partition.Name)
+			}
+			continue
+		}
+
+		// Only drop the partitions whose rows are no longer referenced, which happens once the partitions
+		// of the referencing tables having the same id have been dropped
+		referenced, err := db.isPartitionReferenced(table, partition.ID)
+		if err != nil {
+			return dropped, err
+		}
+		if referenced {
+			continue
+		}
+
+		err = db.DropPartition(table, partition.ID)
+		if err != nil {
+			return dropped, err
+		}
+		dropped = append(dropped, partition.Name)
+	}
+
+	return dropped, nil
+}
+
+// rebuildPrunedPartition drops the partition of the given table having the given id, moving the rows
+// having a height that is a multiple of any of the keepEvery values into a new partition with the same id.
+// The partitions of the referencing tables having the same id are rebuilt along with it, keeping all their rows.
+// Partitions that only contain rows to be kept are left untouched.
+// It returns true if the partition has been rebuilt.
+func (db *Database) rebuildPrunedPartition(table string, partitionID int64, keepEvery []int64) (bool, error) {
+	partition := getPartitionName(table, partitionID)
+	keptCondition := `EXISTS (SELECT 1 FROM unnest($1::BIGINT[]) AS keep_every WHERE height % keep_every = 0)`
+
+	var prunable bool
+	stmt := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE NOT %s)`, partition, keptCondition)
+	err := db.SQL.QueryRow(stmt, pq.Array(keepEvery)).Scan(&prunable)
+	if err != nil {
+		return false, fmt.Errorf("error while checking partition %s: %s", partition, err)
+	}
+	if !prunable {
+		return false, nil
+	}
+
+	// The referencing partitions need to be dropped before the referenced one, and created after it
+	var referencing []string
+	for _, referencingTable := range referencingPartitions[table] {
+		var exists bool
+		err = db.SQL.QueryRow(`SELECT to_regclass($1) IS NOT NULL`, getPartitionName(referencingTable, partitionID)).Scan(&exists)
+		if err != nil {
+			return false, fmt.Errorf("error while checking partition %s: %s", getPartitionName(referencingTable, partitionID), err)
+		}
+		if exists {
+			referencing = append(referencing, referencingTable)
+		}
+	}
+
+	tx, err := db.SQL.Beginx()
+	if err != nil {
+		return false, fmt.Errorf("error while starting transaction: %s", err)
+	}
+	defer tx.Rollback()
+
+	// Copy the rows to keep into temporary tables that are dropped once the transaction is committed
+	stmt = fmt.Sprintf(`CREATE TEMP TABLE %[1]s_kept ON COMMIT DROP AS SELECT * FROM %[1]s WHERE %[2]s`, partition, keptCondition)
+	_, err = tx.Exec(stmt, pq.Array(keepEvery))
+	if err != nil {
+		return false, fmt.Errorf("error while copying the kept rows of partition %s: %s", partition, err)
+	}
+
+	for _, referencingTable := range referencing {
+		referencingPartition := getPartitionName(referencingTable, partitionID)
+		_, err = tx.Exec(fmt.Sprintf(`CREATE TEMP TABLE %[1]s_kept ON COMMIT DROP AS SELECT * FROM %[1]s`, referencingPartition))
+		if err != nil {
+			return false, fmt.Errorf("error while copying the rows of partition %s: %s", referencingPartition, err)
+		}
+	}
+
+	// Drop the partitions, starting from the referencing ones
+	dropped := append(append([]string{}, referencing...), table)
+	for _, droppedTable := range dropped {
+		droppedPartition := getPartitionName(droppedTable, partitionID)
+		_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE %s DETACH PARTITION %s`, droppedTable, droppedPartition))
+		if err != nil {
+			return false, fmt.Errorf("error while detaching partition %s: %s", droppedPartition, err)
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`DROP TABLE %s`, droppedPartition))
+		if err != nil {
+			return false, fmt.Errorf("error while dropping partition %s: %s", droppedPartition, err)
+		}
+	}
+
+	// Create the partitions again and move the kept rows into them, starting from the referenced one
+	for i := len(dropped) - 1; i >= 0; i-- {
+		createdPartition := getPartitionName(dropped[i], partitionID)
+		_, err = tx.Exec(fmt.Sprintf(`CREATE TABLE %s PARTITION OF %s FOR VALUES IN (%d)`, createdPartition, dropped[i], partitionID))
+		if err != nil {
+			return false, fmt.Errorf("error while creating partition %s: %s", createdPartition, err)
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`INSERT INTO %[1]s SELECT * FROM %[1]s_kept`, createdPartition))
+		if err != nil {
+			return false, fmt.Errorf("error while moving the kept rows into partition %s: %s", createdPartition, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, fmt.Errorf("error while committing transaction: %s", err)
+	}
+
+	return true, nil
+}
+
+// isPartitionReferenced tells whether any of the tables referencing the given one still has
+// the partition having the given id
+func (db *Database) isPartitionReferenced(table string, partitionID int64) (bool, error) {
+	for _, referencing := range referencingPartitions[table] {
+		var exists bool
+		err := db.SQL.QueryRow(`SELECT to_regclass($1) IS NOT NULL`, getPartitionName(referencing, partitionID)).Scan(&exists)
+		if err != nil {
+			return false, fmt.Errorf("error while checking partition %s: %s", getPartitionName(referencing, partitionID), err)
+		}
+		if exists {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetLowestHeight implements database.RetentionPruningDb
+func (db *Database) GetLowestHeight(table string) (int64, error) {
+	err := checkPrunableTable(table)
+	if err != nil {
+		return 0, err
+	}
+
+	var height int64
+	err = db.SQL.QueryRow(fmt.Sprintf(`SELECT coalesce(MIN(height), 0) FROM %s`, table)).Scan(&height)
+	return height, err
+}
+
+// GetLastHeightBefore implements database.RetentionPruningDb
+func (db *Database) GetLastHeightBefore(timestamp time.Time) (int64, error) {
+	var height int64
+	err := db.SQL.QueryRow(`SELECT coalesce(MAX(height), 0) FROM block WHERE timestamp < $1`, timestamp.UTC()).Scan(&height)
+	return height, err
+}
+
+// GetLastBlockHeightAndTime implements database.RetentionPruningDb
+func (db *Database) GetLastBlockHeightAndTime() (int64, time.Time, error) {
+	var height int64
+	var timestamp time.Time
+	err := db.SQL.QueryRow(`SELECT height, timestamp FROM block ORDER BY height DESC LIMIT 1`).Scan(&height, &timestamp)
+	if err == sql.ErrNoRows {
+		return 0, time.Time{}, nil
+	}
+	return height, timestamp, err
+}
+
+// -------------------------------------------------------------------------------------------------------------------
+
+// SaveModuleState implements database.ModuleStoreDb
+func (db *Database) SaveModuleState(namespace, key string, height int64, value json.RawMessage) error {
+	stmt := `
+INSERT INTO module_state (namespace, key, height, value) 
+VALUES ($1, $2, $3, $4) 
+ON CONFLICT (namespace, key, height) DO UPDATE 
+	SET value = excluded.value`
+
+	_, err := db.SQL.Exec(stmt, namespace, key, height, string(value))
+	return err
+}
+
+// GetModuleState implements database.ModuleStoreDb
+func (db *Database) GetModuleState(namespace, key string, height int64) (json.RawMessage, int64, error) {
+	stmt := `
+SELECT value, height FROM module_state 
+WHERE namespace = $1 AND key = $2 AND height <= $3 
+ORDER BY height DESC LIMIT 1`
+
+	var value string
+	var valueHeight int64
+	err := db.SQL.QueryRow(stmt, namespace, key, height).Scan(&value, &valueHeight)
+	if err == sql.ErrNoRows {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return json.RawMessage(value), valueHeight, nil
+}
+
+// DeleteModuleState implements database.ModuleStoreDb
+func (db *Database) DeleteModuleState(namespace, key string) error {
+	_, err := db.SQL.Exec(`DELETE FROM module_state WHERE namespace = $1 AND key = $2`, namespace, key)
+	return err
+}
+
+// PruneModuleStates implements database.ModuleStoreDb
+func (db *Database) PruneModuleStates(height int64) error {
+	stmt := `
+DELETE FROM module_state 
+WHERE height < $1 AND EXISTS(
+	SELECT 1 FROM module_state AS newer 
+	WHERE newer.namespace = module_state.namespace 
+	  AND newer.key = module_state.key 
+	  AND newer.height > module_state.height 
+	  AND newer.height <= $1
+)`
+
+	_, err := db.SQL.Exec(stmt, height)
 	return err
 }
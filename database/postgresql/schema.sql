@@ -61,21 +61,41 @@ CREATE INDEX transaction_partition_id_index ON transaction (partition_id);
 
 CREATE TABLE message
 (
-    transaction_hash            TEXT   NOT NULL,
-    index                       BIGINT NOT NULL,
-    type                        TEXT   NOT NULL,
-    value                       JSONB  NOT NULL,
-    involved_accounts_addresses TEXT[] NOT NULL,
+    transaction_hash                TEXT   NOT NULL,
+    index                           BIGINT NOT NULL,
+    type                            TEXT   NOT NULL,
+    value                           JSONB  NOT NULL,
+    involved_accounts_addresses     TEXT[] NOT NULL,
+    raw_involved_accounts_addresses TEXT[] NOT NULL DEFAULT '{}',
+    decoded_value                   JSONB,
+    decode_error                    TEXT,
 
     /* PSQL partition */
-    partition_id                BIGINT NOT NULL DEFAULT 0,
-    height                      BIGINT NOT NULL,
+    partition_id                    BIGINT NOT NULL DEFAULT 0,
+    height                          BIGINT NOT NULL,
     FOREIGN KEY (transaction_hash, partition_id) REFERENCES transaction (hash, partition_id),
     CONSTRAINT unique_message_per_tx UNIQUE (transaction_hash, index, partition_id)
 ) PARTITION BY LIST (partition_id);
 CREATE INDEX message_transaction_hash_index ON message (transaction_hash);
 CREATE INDEX message_type_index ON message (type);
 CREATE INDEX message_involved_accounts_index ON message USING GIN(involved_accounts_addresses);
+CREATE INDEX message_raw_involved_accounts_index ON message USING GIN(raw_involved_accounts_addresses);
+
+CREATE TABLE address_message
+(
+    address      TEXT   NOT NULL,
+    tx_hash      TEXT   NOT NULL,
+    msg_index    BIGINT NOT NULL,
+    role         TEXT   NOT NULL,
+
+    /* PSQL partition */
+    partition_id BIGINT NOT NULL DEFAULT 0,
+    height       BIGINT NOT NULL,
+    CONSTRAINT unique_address_message UNIQUE (address, tx_hash, msg_index, role, partition_id)
+) PARTITION BY LIST (partition_id);
+CREATE INDEX address_message_address_height_index ON address_message (address, height DESC);
+CREATE INDEX address_message_tx_hash_index ON address_message (tx_hash);
+CREATE INDEX address_message_height_index ON address_message (height);
 
 /**
  * This function is used to find all the utils that involve any of the given addresses and have
@@ -90,11 +110,21 @@ CREATE FUNCTION messages_by_address(
 $$
 SELECT * FROM message
 WHERE (cardinality(types) = 0 OR type = ANY (types))
-  AND addresses && involved_accounts_addresses
+  AND (addresses && involved_accounts_addresses OR addresses && raw_involved_accounts_addresses)
 ORDER BY height DESC LIMIT "limit" OFFSET "offset"
 $$ LANGUAGE sql STABLE;
 
 CREATE TABLE pruning
 (
     last_pruned_height BIGINT NOT NULL
-)
\ No newline at end of file
+);
+
+CREATE TABLE module_state
+(
+    namespace TEXT   NOT NULL,
+    key       TEXT   NOT NULL,
+    height    BIGINT NOT NULL,
+    value     JSONB  NOT NULL,
+    PRIMARY KEY (namespace, key, height)
+);
+CREATE INDEX module_state_height_index ON module_state (height)
\ No newline at end of file
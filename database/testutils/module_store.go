@@ -0,0 +1,89 @@
+package testutils
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/forbole/juno/v5/database"
+)
+
+var (
+	_ database.ModuleStoreDb = &ModuleStoreDb{}
+)
+
+// ModuleStoreDb represents an in-memory database.ModuleStoreDb implementation, to be used for testing purposes.
+// All the versions of each value are kept, so that it behaves like the PostgreSQL implementation.
+// It can be embedded inside other mock databases in order to add the module store support to them.
+type ModuleStoreDb struct {
+	database.Database
+
+	mu     sync.Mutex
+	values map[string]map[int64]json.RawMessage
+}
+
+// NewModuleStoreDb returns a new empty ModuleStoreDb instance
+func NewModuleStoreDb() *ModuleStoreDb {
+	return &ModuleStoreDb{
+		values: map[string]map[int64]json.RawMessage{},
+	}
+}
+
+// SaveModuleState implements database.ModuleStoreDb
+func (db *ModuleStoreDb) SaveModuleState(namespace, key string, height int64, value json.RawMessage) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	id := namespace + "/" + key
+	if db.values[id] == nil {
+		db.values[id] = map[int64]json.RawMessage{}
+	}
+	db.values[id][height] = value
+	return nil
+}
+
+// GetModuleState implements database.ModuleStoreDb
+func (db *ModuleStoreDb) GetModuleState(namespace, key string, height int64) (json.RawMessage, int64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var value json.RawMessage
+	var valueHeight int64
+	for h, v := range db.values[namespace+"/"+key] {
+		if h <= height && (value == nil || h > valueHeight) {
+			value, valueHeight = v, h
+		}
+	}
+	return value, valueHeight, nil
+}
+
+// DeleteModuleState implements database.ModuleStoreDb
+func (db *ModuleStoreDb) DeleteModuleState(namespace, key string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	delete(db.values, namespace+"/"+key)
+	return nil
+}
+
+// PruneModuleStates implements database.ModuleStoreDb
+func (db *ModuleStoreDb) PruneModuleStates(height int64) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, versions := range db.values {
+		// Find the version of the value at the given height, which must be kept
+		var keptHeight int64 = -1
+		for h := range versions {
+			if h <= height && h > keptHeight {
+				keptHeight = h
+			}
+		}
+
+		for h := range versions {
+			if h < keptHeight {
+				delete(versions, h)
+			}
+		}
+	}
+	return nil
+}
@@ -68,7 +68,7 @@ func (d *defaultLogger) Debug(msg string, keyVals ...interface{}) {
 
 // Error implements Logger
 func (d *defaultLogger) Error(msg string, keyVals ...interface{}) {
-	ErrorCount.Inc()
+	ErrorCount.WithLabelValues(getModuleLabel(keyVals...)).Inc()
 	d.Logger.Error().Fields(getLogFields(keyVals...)).Msg(msg)
 }
 
@@ -131,3 +131,13 @@ func getLogFields(keyVals ...interface{}) map[string]interface{} {
 
 	return fields
 }
+
+// getModuleLabel returns the name of the module associated to the given key values, or an empty string if none
+func getModuleLabel(keyVals ...interface{}) string {
+	for i := 0; i+1 < len(keyVals); i += 2 {
+		if keyVals[i] == LogKeyModule {
+			return fmt.Sprintf("%v", keyVals[i+1])
+		}
+	}
+	return ""
+}
@@ -12,9 +12,9 @@ var StartHeight = prometheus.NewCounter(
 	},
 )
 
-// WorkerCount represents the Telemetry counter used to track the worker count
-var WorkerCount = prometheus.NewCounter(
-	prometheus.CounterOpts{
+// WorkerCount represents the Telemetry gauge used to track the number of active workers
+var WorkerCount = prometheus.NewGauge(
+	prometheus.GaugeOpts{
 		Name: "juno_worker_count",
 		Help: "Number of active workers.",
 	},
@@ -29,12 +29,14 @@ var WorkerHeight = prometheus.NewGaugeVec(
 	[]string{"worker_index", "chain_id"},
 )
 
-// ErrorCount represents the Telemetry counter used to track the number of errors emitted
-var ErrorCount = prometheus.NewCounter(
+// ErrorCount represents the Telemetry counter used to track the number of errors emitted by each module.
+// Errors that are not related to any module have an empty module label.
+var ErrorCount = prometheus.NewCounterVec(
 	prometheus.CounterOpts{
 		Name: "juno_error_count",
 		Help: "Total number of errors emitted.",
 	},
+	[]string{"module"},
 )
 
 var DbBlockCount = prometheus.NewGaugeVec(
@@ -54,34 +56,120 @@ var DbLatestHeight = prometheus.NewGaugeVec(
 	[]string{"db_latest_height"},
 )
 
-func init() {
-	err := prometheus.Register(StartHeight)
-	if err != nil {
-		panic(err)
-	}
+// ModulePanicCount represents the Telemetry counter used to track the number of panics of each module
+var ModulePanicCount = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "juno_module_panic_count",
+		Help: "Total number of panics recovered for each module.",
+	},
+	[]string{"module"},
+)
 
-	err = prometheus.Register(WorkerCount)
-	if err != nil {
-		panic(err)
-	}
+// ModuleQuarantined represents the Telemetry gauge used to track which modules have been quarantined
+var ModuleQuarantined = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "juno_module_quarantined",
+		Help: "Whether a module has been quarantined (1) or not (0).",
+	},
+	[]string{"module"},
+)
 
-	err = prometheus.Register(WorkerHeight)
-	if err != nil {
-		panic(err)
-	}
+// NodeCallDuration represents the Telemetry histogram used to track the time spent calling each node method
+var NodeCallDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "juno_node_call_duration_seconds",
+		Help:    "Time spent calling each node method.",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"method"},
+)
 
-	err = prometheus.Register(ErrorCount)
-	if err != nil {
-		panic(err)
-	}
+// DbWriteDuration represents the Telemetry histogram used to track the time spent performing each database write
+var DbWriteDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "juno_db_write_duration_seconds",
+		Help:    "Time spent performing each database write.",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"operation"},
+)
 
-	err = prometheus.Register(DbBlockCount)
-	if err != nil {
-		panic(err)
+// ModuleHandlerDuration represents the Telemetry histogram used to track the time spent inside each module handler.
+// The message type label is set only for the messages handlers.
+var ModuleHandlerDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "juno_module_handler_duration_seconds",
+		Help:    "Time spent inside each module handler.",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"module", "handler", "message_type"},
+)
+
+// ProcessedBlocks represents the Telemetry counter used to track the number of processed blocks
+var ProcessedBlocks = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Name: "juno_processed_blocks_total",
+		Help: "Total number of processed blocks.",
+	},
+)
+
+// ProcessedTxs represents the Telemetry counter used to track the number of processed transactions
+var ProcessedTxs = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Name: "juno_processed_txs_total",
+		Help: "Total number of processed transactions.",
+	},
+)
+
+// ProcessedMessages represents the Telemetry counter used to track the number of processed messages of each type
+var ProcessedMessages = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "juno_processed_messages_total",
+		Help: "Total number of processed messages.",
+	},
+	[]string{"message_type"},
+)
+
+// QueueDepth represents the Telemetry gauge used to track the number of heights waiting to be parsed
+var QueueDepth = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "juno_queue_depth",
+		Help: "Number of heights waiting to be parsed.",
+	},
+)
+
+// ChainHeadLag represents the Telemetry gauge used to track how many heights the database is behind the chain head
+var ChainHeadLag = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "juno_chain_head_lag",
+		Help: "Number of heights between the chain head and the latest height in the database.",
+	},
+)
+
+func init() {
+	collectors := []prometheus.Collector{
+		StartHeight,
+		WorkerCount,
+		WorkerHeight,
+		ErrorCount,
+		DbBlockCount,
+		DbLatestHeight,
+		ModulePanicCount,
+		ModuleQuarantined,
+		NodeCallDuration,
+		DbWriteDuration,
+		ModuleHandlerDuration,
+		ProcessedBlocks,
+		ProcessedTxs,
+		ProcessedMessages,
+		QueueDepth,
+		ChainHeadLag,
 	}
 
-	err = prometheus.Register(DbLatestHeight)
-	if err != nil {
-		panic(err)
+	for _, collector := range collectors {
+		err := prometheus.Register(collector)
+		if err != nil {
+			panic(err)
+		}
 	}
 }
@@ -0,0 +1,71 @@
+package modules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortByDependencies returns the given modules sorted so that each module comes after all the modules it depends on,
+// as declared using the DependentModule interface. Modules that do not depend on each other keep their original
+// relative order. An error is returned if any dependency is not present among the given modules, or if the
+// dependencies contain a cycle.
+func SortByDependencies(mods []Module) ([]Module, error) {
+	indexes := make(map[string]int, len(mods))
+	for i, module := range mods {
+		indexes[strings.ToLower(module.Name())] = i
+	}
+
+	// Build the dependency graph
+	inDegree := make([]int, len(mods))
+	dependents := make([][]int, len(mods))
+	for i, module := range mods {
+		dependentModule, ok := module.(DependentModule)
+		if !ok {
+			continue
+		}
+
+		for _, dependency := range dependentModule.DependsOn() {
+			j, found := indexes[strings.ToLower(dependency)]
+			if !found {
+				return nil, fmt.Errorf("module %s depends on module %s which is not enabled", module.Name(), dependency)
+			}
+			if j == i {
+				return nil, fmt.Errorf("module %s depends on itself", module.Name())
+			}
+
+			dependents[j] = append(dependents[j], i)
+			inDegree[i]++
+		}
+	}
+
+	// Sort the modules by always picking the first one in the original order without pending dependencies
+	sorted := make([]Module, 0, len(mods))
+	visited := make([]bool, len(mods))
+	for len(sorted) < len(mods) {
+		next := -1
+		for i := range mods {
+			if !visited[i] && inDegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+
+		if next == -1 {
+			var cycle []string
+			for i, module := range mods {
+				if !visited[i] {
+					cycle = append(cycle, module.Name())
+				}
+			}
+			return nil, fmt.Errorf("dependency cycle found involving modules: %s", strings.Join(cycle, ", "))
+		}
+
+		visited[next] = true
+		sorted = append(sorted, mods[next])
+		for _, dependent := range dependents[next] {
+			inDegree[dependent]--
+		}
+	}
+
+	return sorted, nil
+}
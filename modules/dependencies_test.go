@@ -0,0 +1,45 @@
+package modules_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/modules"
+)
+
+type mockModule struct {
+	name         string
+	dependencies []string
+}
+
+func (m mockModule) Name() string {
+	return m.name
+}
+
+func (m mockModule) DependsOn() []string {
+	return m.dependencies
+}
+
+func TestSortByDependencies(t *testing.T) {
+	sorted, err := modules.SortByDependencies([]modules.Module{
+		mockModule{name: "gov", dependencies: []string{"staking", "auth"}},
+		mockModule{name: "messages"},
+		mockModule{name: "staking", dependencies: []string{"auth"}},
+		mockModule{name: "auth"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"messages", "auth", "staking", "gov"}, modules.Modules(sorted).Names())
+
+	_, err = modules.SortByDependencies([]modules.Module{
+		mockModule{name: "gov", dependencies: []string{"staking"}},
+	})
+	require.ErrorContains(t, err, "not enabled")
+
+	_, err = modules.SortByDependencies([]modules.Module{
+		mockModule{name: "auth"},
+		mockModule{name: "gov", dependencies: []string{"staking"}},
+		mockModule{name: "staking", dependencies: []string{"gov"}},
+	})
+	require.ErrorContains(t, err, "gov, staking")
+}
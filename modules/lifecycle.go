@@ -0,0 +1,70 @@
+package modules
+
+import (
+	"fmt"
+)
+
+// InitModules initializes all the given modules implementing InitializableModule, in order.
+// Before that, all the modules implementing ModulesAwareModule are provided with the given modules.
+// An error is returned as soon as any module fails to initialize.
+func InitModules(mods []Module) error {
+	for _, module := range mods {
+		if modulesAware, ok := module.(ModulesAwareModule); ok {
+			modulesAware.SetModules(mods)
+		}
+	}
+
+	for _, module := range mods {
+		if initializable, ok := module.(InitializableModule); ok {
+			err := initializable.Init()
+			if err != nil {
+				return fmt.Errorf("error while initializing module %s: %s", module.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// StartModules starts all the given modules implementing StartableModule, in order.
+// An error is returned as soon as any module fails to start.
+func StartModules(mods []Module) error {
+	for _, module := range mods {
+		if startable, ok := module.(StartableModule); ok {
+			err := startable.Start()
+			if err != nil {
+				return fmt.Errorf("error while starting module %s: %s", module.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// StopModules stops all the given modules implementing StoppableModule, in reverse order so that
+// each module is stopped before the ones it depends on.
+// All the modules are stopped even if some of them fail, and the returned map contains the errors
+// returned by each failed module, indexed by the module name.
+func StopModules(mods []Module) map[string]error {
+	errs := map[string]error{}
+	for i := len(mods) - 1; i >= 0; i-- {
+		if stoppable, ok := mods[i].(StoppableModule); ok {
+			err := stoppable.Stop()
+			if err != nil {
+				errs[mods[i].Name()] = err
+			}
+		}
+	}
+	return errs
+}
+
+// HealthCheck checks the health of all the given modules implementing HealthCheckModule.
+// The returned map contains the health of each one of such modules, indexed by the module name:
+// a nil value means that the module is healthy.
+func HealthCheck(mods []Module) map[string]error {
+	health := map[string]error{}
+	for _, module := range mods {
+		if checkable, ok := module.(HealthCheckModule); ok {
+			health[module.Name()] = checkable.HealthCheck()
+		}
+	}
+	return health
+}
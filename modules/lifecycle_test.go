@@ -0,0 +1,87 @@
+package modules_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/modules"
+)
+
+// lifecycleModule represents a module implementing all the lifecycle interfaces, recording every call
+type lifecycleModule struct {
+	name  string
+	err   error
+	calls *[]string
+}
+
+func (m lifecycleModule) Name() string {
+	return m.name
+}
+
+func (m lifecycleModule) record(method string) error {
+	*m.calls = append(*m.calls, fmt.Sprintf("%s.%s", m.name, method))
+	return m.err
+}
+
+func (m lifecycleModule) Init() error {
+	return m.record("init")
+}
+
+func (m lifecycleModule) Start() error {
+	return m.record("start")
+}
+
+func (m lifecycleModule) Stop() error {
+	return m.record("stop")
+}
+
+func (m lifecycleModule) HealthCheck() error {
+	return m.err
+}
+
+func TestLifecycle(t *testing.T) {
+	var calls []string
+	mods := []modules.Module{
+		lifecycleModule{name: "auth", calls: &calls},
+		mockModule{name: "messages"},
+		lifecycleModule{name: "bank", calls: &calls},
+	}
+
+	require.NoError(t, modules.InitModules(mods))
+	require.NoError(t, modules.StartModules(mods))
+	require.Empty(t, modules.StopModules(mods))
+
+	// Modules should be stopped in reverse order
+	require.Equal(t, []string{
+		"auth.init", "bank.init",
+		"auth.start", "bank.start",
+		"bank.stop", "auth.stop",
+	}, calls)
+}
+
+func TestLifecycle_Errors(t *testing.T) {
+	var calls []string
+	mods := []modules.Module{
+		lifecycleModule{name: "auth", calls: &calls, err: fmt.Errorf("invalid config")},
+		lifecycleModule{name: "bank", calls: &calls},
+	}
+
+	// Initialization should stop at the first error
+	err := modules.InitModules(mods)
+	require.EqualError(t, err, "error while initializing module auth: invalid config")
+	require.Equal(t, []string{"auth.init"}, calls)
+
+	// All the modules should be stopped even if some of them fail
+	calls = nil
+	errs := modules.StopModules(mods)
+	require.Equal(t, []string{"bank.stop", "auth.stop"}, calls)
+	require.Len(t, errs, 1)
+	require.EqualError(t, errs["auth"], "invalid config")
+
+	health := modules.HealthCheck(mods)
+	require.Len(t, health, 2)
+	require.Error(t, health["auth"])
+	require.NoError(t, health["bank"])
+}
@@ -0,0 +1,134 @@
+package messages
+
+import (
+	"encoding/hex"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+
+	"github.com/forbole/juno/v5/types"
+)
+
+// validatorPrefixSuffix represents the suffix that is appended to a bech32 prefix to get the validator operators prefix
+const validatorPrefixSuffix = sdk.PrefixValidator + sdk.PrefixOperator
+
+// AddressCodec allows to recognize the addresses having different formats (eg. the bech32 addresses of other
+// chains, or the 0x hex addresses), and to convert them to their canonical form, which is the bech32 format using
+// the chain account (or validator operator) prefix.
+type AddressCodec struct {
+	prefixes     []string
+	hexAddresses bool
+}
+
+// NewAddressCodec returns a new AddressCodec instance recognizing the given additional
+// bech32 prefixes, as well as the 0x hex addresses if hexAddresses is true
+func NewAddressCodec(prefixes []string, hexAddresses bool) *AddressCodec {
+	return &AddressCodec{
+		prefixes:     prefixes,
+		hexAddresses: hexAddresses,
+	}
+}
+
+// DefaultAddressCodec returns the AddressCodec recognizing only the addresses having the chain prefix
+func DefaultAddressCodec() *AddressCodec {
+	return NewAddressCodec(nil, false)
+}
+
+// Normalize returns the canonical form of the given address.
+// If the given value is not a recognized address, false is returned instead.
+func (c *AddressCodec) Normalize(value string) (string, bool) {
+	sdkConfig := sdk.GetConfig()
+
+	if c.hexAddresses && isHexAddress(value) {
+		bz, err := hex.DecodeString(value[2:])
+		if err != nil {
+			return "", false
+		}
+		return encodeAddress(sdkConfig.GetBech32AccountAddrPrefix(), bz)
+	}
+
+	hrp, bz, err := bech32.DecodeAndConvert(value)
+	if err != nil || sdk.VerifyAddressFormat(bz) != nil {
+		return "", false
+	}
+
+	switch {
+	case hrp == sdkConfig.GetBech32AccountAddrPrefix() || contains(c.prefixes, hrp):
+		return encodeAddress(sdkConfig.GetBech32AccountAddrPrefix(), bz)
+
+	case hrp == sdkConfig.GetBech32ValidatorAddrPrefix() || c.isValidatorPrefix(hrp):
+		return encodeAddress(sdkConfig.GetBech32ValidatorAddrPrefix(), bz)
+
+	default:
+		return "", false
+	}
+}
+
+// NormalizeAll returns the canonical form of all the given addresses, removing the duplicates.
+// Values that are not recognized as addresses are kept as they are.
+func (c *AddressCodec) NormalizeAll(values []string) []string {
+	normalized := make([]string, len(values))
+	for i, value := range values {
+		canonical, ok := c.Normalize(value)
+		if !ok {
+			canonical = value
+		}
+		normalized[i] = canonical
+	}
+	return removeDuplicates(normalized)
+}
+
+// isValidatorPrefix tells whether the given bech32 prefix is the validator operators prefix of one of the
+// additional prefixes
+func (c *AddressCodec) isValidatorPrefix(hrp string) bool {
+	for _, prefix := range c.prefixes {
+		if hrp == prefix+validatorPrefixSuffix {
+			return true
+		}
+	}
+	return false
+}
+
+// isHexAddress tells whether the given value represents a 0x hex address
+func isHexAddress(value string) bool {
+	if len(value) != 42 || !strings.HasPrefix(value, "0x") && !strings.HasPrefix(value, "0X") {
+		return false
+	}
+
+	_, err := hex.DecodeString(value[2:])
+	return err == nil
+}
+
+// encodeAddress encodes the given address bytes using the given bech32 prefix
+func encodeAddress(prefix string, bz []byte) (string, bool) {
+	encoded, err := bech32.ConvertAndEncode(prefix, bz)
+	if err != nil {
+		return "", false
+	}
+	return encoded, true
+}
+
+// contains tells whether the given slice contains the given value
+func contains(slice []string, value string) bool {
+	for _, v := range slice {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeMessageAddresses returns the given message addresses using the canonical form of each address,
+// removing the duplicates. Values that are not recognized as addresses are kept as they are.
+func (c *AddressCodec) normalizeMessageAddresses(msgAddresses []types.MessageAddress) []types.MessageAddress {
+	normalized := make([]types.MessageAddress, len(msgAddresses))
+	for i, msgAddress := range msgAddresses {
+		canonical, ok := c.Normalize(msgAddress.Address)
+		if !ok {
+			canonical = msgAddress.Address
+		}
+		normalized[i] = types.NewMessageAddress(canonical, msgAddress.Role)
+	}
+	return removeDuplicateMessageAddresses(normalized)
+}
@@ -0,0 +1,58 @@
+package messages_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/modules/messages"
+)
+
+func TestAddressCodec_Normalize(t *testing.T) {
+	bz := []byte("address_____________")
+	account := sdk.AccAddress(bz).String()
+	validator := sdk.ValAddress(bz).String()
+
+	osmoAccount, err := bech32.ConvertAndEncode("osmo", bz)
+	require.NoError(t, err)
+	osmoValidator, err := bech32.ConvertAndEncode("osmovaloper", bz)
+	require.NoError(t, err)
+	junoAccount, err := bech32.ConvertAndEncode("juno", bz)
+	require.NoError(t, err)
+	hexAccount := "0x" + hex.EncodeToString(bz)
+
+	codec := messages.NewAddressCodec([]string{"osmo"}, true)
+	testCases := []struct {
+		name      string
+		value     string
+		canonical string
+		ok        bool
+	}{
+		{name: "chain account address", value: account, canonical: account, ok: true},
+		{name: "chain validator address", value: validator, canonical: validator, ok: true},
+		{name: "additional prefix account address", value: osmoAccount, canonical: account, ok: true},
+		{name: "additional prefix validator address", value: osmoValidator, canonical: validator, ok: true},
+		{name: "hex address", value: hexAccount, canonical: account, ok: true},
+		{name: "unknown prefix address", value: junoAccount, ok: false},
+		{name: "invalid address", value: "uatom", ok: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			canonical, ok := codec.Normalize(tc.value)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.canonical, canonical)
+		})
+	}
+
+	// Hex addresses should not be recognized unless enabled
+	_, ok := messages.DefaultAddressCodec().Normalize(hexAccount)
+	require.False(t, ok)
+
+	// All the forms should be normalized to the same address
+	require.Equal(t, []string{account}, codec.NormalizeAll([]string{account, osmoAccount, hexAccount}))
+}
@@ -0,0 +1,57 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/forbole/juno/v5/types/config"
+)
+
+var (
+	_ config.ModuleConfig = &Config{}
+)
+
+func init() {
+	config.RegisterModuleConfig(ModuleName, "messages", func() config.ModuleConfig {
+		return DefaultConfig()
+	})
+}
+
+// Config represents the configuration of the messages module
+type Config struct {
+	// AddressPrefixes contains the additional bech32 prefixes of the addresses that should be recognized
+	// (eg. the prefixes of the IBC counterparty chains). The chain prefix is always recognized.
+	AddressPrefixes []string `yaml:"address_prefixes"`
+
+	// HexAddresses tells whether the 0x hex addresses (eg. the EVM addresses of Ethermint chains)
+	// should be recognized as well
+	HexAddresses bool `yaml:"hex_addresses"`
+
+	// DecodeMessages tells whether the messages should be decoded into their concrete types
+	// and stored alongside their raw JSON representation
+	DecodeMessages bool `yaml:"decode_messages"`
+}
+
+// NewConfig allows to build a new Config instance
+func NewConfig(addressPrefixes []string, hexAddresses bool, decodeMessages bool) *Config {
+	return &Config{
+		AddressPrefixes: addressPrefixes,
+		HexAddresses:    hexAddresses,
+		DecodeMessages:  decodeMessages,
+	}
+}
+
+// DefaultConfig returns the default Config instance
+func DefaultConfig() *Config {
+	return NewConfig(nil, false, false)
+}
+
+// Validate implements config.ModuleConfig
+func (c *Config) Validate() error {
+	for _, prefix := range c.AddressPrefixes {
+		if prefix == "" || prefix != strings.ToLower(prefix) {
+			return fmt.Errorf("invalid address prefix: %s", prefix)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/forbole/juno/v5/types"
+)
+
+// DecodeMessage uses the given codec to unpack the given message into its concrete sdk.Msg type, and returns it as a
+// types.DecodedMessage. The decoded value is the JSON representation of the concrete message, in which:
+//   - nested google.protobuf.Any values are resolved into the messages they contain (along with their @type);
+//   - integers (including uint64 and int64) are encoded as numbers;
+//   - enums are encoded using their names.
+//
+// If the message cannot be decoded (eg. its type is not registered inside the codec), the error is
+// stored inside the DecodeError field of the returned message instead.
+func DecodeMessage(cdc codec.Codec, msg types.Message) *types.DecodedMessage {
+	var sdkMsg sdk.Msg
+	err := cdc.UnmarshalInterfaceJSON(msg.GetBytes(), &sdkMsg)
+	if err != nil {
+		return types.NewDecodedMessage(msg, nil, fmt.Sprintf("error while unpacking message: %s", err))
+	}
+
+	value := decodeValue(reflect.ValueOf(sdkMsg))
+	if object, ok := value.(map[string]interface{}); ok {
+		object["@type"] = msg.GetType()
+	}
+
+	bz, err := json.Marshal(value)
+	if err != nil {
+		return types.NewDecodedMessage(msg, nil, fmt.Sprintf("error while serializing message: %s", err))
+	}
+
+	return types.NewDecodedMessage(msg, bz, "")
+}
+
+var (
+	anyType        = reflect.TypeOf(codectypes.Any{})
+	marshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	enumType       = reflect.TypeOf((*protoEnum)(nil)).Elem()
+	bytesSliceType = reflect.TypeOf([]byte(nil))
+)
+
+// protoEnum represents a protobuf enum generated by gogoproto
+type protoEnum interface {
+	fmt.Stringer
+	EnumDescriptor() ([]byte, []int)
+}
+
+// decodeValue converts the given value into a value that can be serialized using the json package
+func decodeValue(value reflect.Value) interface{} {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return nil
+
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		if value.Type() == reflect.PtrTo(anyType) {
+			return decodeAny(value.Interface().(*codectypes.Any))
+		}
+		return decodeValue(value.Elem())
+
+	case reflect.Struct:
+		if value.Type() == anyType {
+			anyValue := value.Interface().(codectypes.Any)
+			return decodeAny(&anyValue)
+		}
+
+		// Custom types (eg. math.Int, sdk.Dec or time.Time) know how to serialize themselves
+		if value.Type().Implements(marshalerType) || reflect.PtrTo(value.Type()).Implements(marshalerType) {
+			return marshalerValue(value)
+		}
+
+		object := map[string]interface{}{}
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			name := getJSONFieldName(field)
+			if name == "" {
+				continue
+			}
+			object[name] = decodeValue(value.Field(i))
+		}
+		return object
+
+	case reflect.Slice, reflect.Array:
+		if value.Type() == bytesSliceType {
+			return value.Interface()
+		}
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return []interface{}{}
+		}
+
+		list := make([]interface{}, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			list[i] = decodeValue(value.Index(i))
+		}
+		return list
+
+	case reflect.Map:
+		object := map[string]interface{}{}
+		iter := value.MapRange()
+		for iter.Next() {
+			object[fmt.Sprint(iter.Key().Interface())] = decodeValue(iter.Value())
+		}
+		return object
+
+	case reflect.Int32:
+		if value.Type().Implements(enumType) {
+			return value.Interface().(protoEnum).String()
+		}
+		return value.Interface()
+
+	default:
+		return value.Interface()
+	}
+}
+
+// decodeAny returns the value contained inside the given Any, along with its type URL.
+// If the value has not been unpacked, the raw bytes are returned instead.
+func decodeAny(anyValue *codectypes.Any) interface{} {
+	cached := anyValue.GetCachedValue()
+	if cached == nil {
+		return map[string]interface{}{"@type": anyValue.TypeUrl, "value": anyValue.Value}
+	}
+
+	value := decodeValue(reflect.ValueOf(cached))
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{"@type": anyValue.TypeUrl, "value": value}
+	}
+
+	object["@type"] = anyValue.TypeUrl
+	return object
+}
+
+// marshalerValue returns the JSON representation of the given value that implements json.Marshaler
+func marshalerValue(value reflect.Value) interface{} {
+	if value.CanAddr() {
+		return value.Addr().Interface()
+	}
+
+	ptr := reflect.New(value.Type())
+	ptr.Elem().Set(value)
+	return ptr.Interface()
+}
+
+// getJSONFieldName returns the name that the given struct field should have inside the JSON representation.
+// An empty string is returned if the field should be skipped.
+func getJSONFieldName(field reflect.StructField) string {
+	if !field.IsExported() || strings.HasPrefix(field.Name, "XXX_") {
+		return ""
+	}
+
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
@@ -0,0 +1,86 @@
+package messages_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/modules/messages"
+	"github.com/forbole/juno/v5/types"
+)
+
+func TestDecodeMessage(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	banktypes.RegisterInterfaces(registry)
+	authz.RegisterInterfaces(registry)
+	govv1.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+
+	sender := sdk.AccAddress("sender______________").String()
+	receiver := sdk.AccAddress("receiver____________").String()
+
+	testCases := []struct {
+		name        string
+		msg         types.Message
+		value       string
+		decodeError bool
+	}{
+		{
+			name: "integers and enums are decoded",
+			msg: types.NewStandardMessage(0, "/cosmos.gov.v1.MsgVote", []byte(fmt.Sprintf(
+				`{"@type":"/cosmos.gov.v1.MsgVote","proposal_id":"10","voter":"%s","option":"VOTE_OPTION_YES","metadata":""}`,
+				sender,
+			))),
+			value: fmt.Sprintf(
+				`{"@type":"/cosmos.gov.v1.MsgVote","metadata":"","option":"VOTE_OPTION_YES","proposal_id":10,"voter":"%s"}`,
+				sender,
+			),
+		},
+		{
+			name: "any values are resolved",
+			msg: types.NewStandardMessage(0, "/cosmos.authz.v1beta1.MsgExec", []byte(fmt.Sprintf(
+				`{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"%s","msgs":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%s","to_address":"%s","amount":[{"denom":"uatom","amount":"10"}]}]}`,
+				receiver, sender, receiver,
+			))),
+			value: fmt.Sprintf(
+				`{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"%s","msgs":[{"@type":"/cosmos.bank.v1beta1.MsgSend","amount":[{"amount":"10","denom":"uatom"}],"from_address":"%s","to_address":"%s"}]}`,
+				receiver, sender, receiver,
+			),
+		},
+		{
+			name:        "unknown types are flagged",
+			msg:         types.NewStandardMessage(0, "/unknown.v1.MsgUnknown", []byte(`{"@type":"/unknown.v1.MsgUnknown"}`)),
+			decodeError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			decoded := messages.DecodeMessage(cdc, tc.msg)
+			require.Equal(t, tc.msg.GetBytes(), decoded.GetBytes())
+
+			if tc.decodeError {
+				require.NotEmpty(t, decoded.DecodeError)
+				require.Nil(t, decoded.Value)
+				return
+			}
+
+			require.Empty(t, decoded.DecodeError)
+			require.JSONEq(t, tc.value, string(decoded.Value))
+
+			// The original message should be serialized as it was
+			bz, err := json.Marshal(decoded)
+			require.NoError(t, err)
+			require.JSONEq(t, string(tc.msg.GetBytes()), string(bz))
+		})
+	}
+}
@@ -0,0 +1,447 @@
+package messages
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/forbole/juno/v5/types"
+)
+
+// MessageAddressesExtractor represents a function that extracts all the
+// addresses involved by a single message (both accounts and validators)
+type MessageAddressesExtractor = func(msg types.Message, tx *types.Transaction) ([]string, error)
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   = map[string]MessageAddressesExtractor{}
+)
+
+// RegisterMessageAddressesExtractor registers the given extractor to be used for all the messages having the
+// given type URL (eg. "/cosmos.bank.v1beta1.MsgSend"), instead of relying on the protobuf annotations.
+func RegisterMessageAddressesExtractor(msgType string, extractor MessageAddressesExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+	extractors[msgType] = extractor
+}
+
+// getMessageAddressesExtractor returns the extractor registered for the given message type, if any
+func getMessageAddressesExtractor(msgType string) (MessageAddressesExtractor, bool) {
+	extractorsMu.RLock()
+	defer extractorsMu.RUnlock()
+	extractor, found := extractors[msgType]
+	return extractor, found
+}
+
+// ParseMessageAddresses returns the addresses involved by the given message of the given transaction,
+// recognizing only the addresses having the chain prefix inside the events.
+// See AddressCodec.ParseMessageAddresses for more details.
+func ParseMessageAddresses(msg types.Message, tx *types.Transaction) ([]string, error) {
+	return DefaultAddressCodec().ParseMessageAddresses(msg, tx)
+}
+
+// ParseMessageAddresses returns the raw addresses involved by the given message of the given transaction.
+// See AddressCodec.ParseMessageAddressesWithRoles for more details.
+func (c *AddressCodec) ParseMessageAddresses(msg types.Message, tx *types.Transaction) ([]string, error) {
+	msgAddresses, err := c.ParseMessageAddressesWithRoles(msg, tx)
+	if err != nil {
+		return nil, err
+	}
+	return getAddresses(msgAddresses), nil
+}
+
+// ParseMessageAddressesWithRoles returns the raw addresses involved by the given message of the given transaction,
+// along with their roles. The addresses are obtained using (in order):
+//   - the extractor registered for the message type, if any;
+//   - the message fields annotated with the cosmos.msg.v1.signer option or with an address cosmos_proto.scalar;
+//   - the attributes of the events emitted by the message itself that are recognized as addresses by the codec.
+//
+// The addresses obtained using a registered extractor or the events have the types.AddressRoleInvolved role.
+func (c *AddressCodec) ParseMessageAddressesWithRoles(msg types.Message, tx *types.Transaction) ([]types.MessageAddress, error) {
+	if extractor, found := getMessageAddressesExtractor(msg.GetType()); found {
+		addresses, err := extractor(msg, tx)
+		if err != nil {
+			return nil, fmt.Errorf("error while extracting addresses of message %s: %s", msg.GetType(), err)
+		}
+		return withRole(addresses, types.AddressRoleInvolved), nil
+	}
+
+	msgAddresses, err := ExtractAnnotatedMessageAddresses(msg)
+	if err == nil && len(msgAddresses) > 0 {
+		return msgAddresses, nil
+	}
+
+	return withRole(c.parseMessageAddressesFromEvents(msg.GetIndex(), tx), types.AddressRoleInvolved), nil
+}
+
+// withRole returns the given addresses with the given role, removing the duplicates
+func withRole(addresses []string, role types.AddressRole) []types.MessageAddress {
+	msgAddresses := make([]types.MessageAddress, 0, len(addresses))
+	for _, address := range removeDuplicates(addresses) {
+		msgAddresses = append(msgAddresses, types.NewMessageAddress(address, role))
+	}
+	return msgAddresses
+}
+
+// getAddresses returns the addresses of the given message addresses, removing the duplicates
+func getAddresses(msgAddresses []types.MessageAddress) []string {
+	addresses := make([]string, len(msgAddresses))
+	for i, msgAddress := range msgAddresses {
+		addresses[i] = msgAddress.Address
+	}
+	return removeDuplicates(addresses)
+}
+
+// removeDuplicateMessageAddresses removes the duplicated message addresses having the same address and role
+func removeDuplicateMessageAddresses(msgAddresses []types.MessageAddress) []types.MessageAddress {
+	bucket := make(map[types.MessageAddress]bool)
+	result := []types.MessageAddress{}
+	for _, msgAddress := range msgAddresses {
+		if !bucket[msgAddress] {
+			bucket[msgAddress] = true
+			result = append(result, msgAddress)
+		}
+	}
+	return result
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+const (
+	// signerOptionNumber represents the field number of the cosmos.msg.v1.signer message option
+	signerOptionNumber protowire.Number = 11110000
+
+	// scalarOptionNumber represents the field number of the cosmos_proto.scalar field option
+	scalarOptionNumber protowire.Number = 93002
+)
+
+// validatorAddressScalar represents the cosmos_proto.scalar value identifying the validator address fields
+const validatorAddressScalar = "cosmos.ValidatorAddressString"
+
+// addressScalars contains the cosmos_proto.scalar values identifying the address fields
+var addressScalars = map[string]bool{
+	"cosmos.AddressString": true,
+	validatorAddressScalar: true,
+}
+
+// maxFieldsDepth represents the max depth at which nested messages are inspected looking for addresses
+const maxFieldsDepth = 5
+
+// addressField represents a message field that contains (or might contain) addresses
+type addressField struct {
+	name     string
+	jsonName string
+
+	// roles contains the roles of the addresses contained inside the field,
+	// in addition to the roles of the parent field
+	roles []types.AddressRole
+
+	// isAny tells whether the field contains a google.protobuf.Any value,
+	// whose addresses are extracted using the type it contains
+	isAny bool
+
+	// nested contains the address fields of the field message type, if the field is not a string
+	nested []addressField
+}
+
+var (
+	addressFieldsMu    sync.RWMutex
+	addressFieldsCache = map[string][]addressField{}
+)
+
+// ExtractAnnotatedAddresses returns the values of the given message fields that are either listed inside the
+// cosmos.msg.v1.signer option, or annotated with an address cosmos_proto.scalar option.
+// Messages contained inside google.protobuf.Any fields (eg. authz MsgExec) are inspected as well.
+// The message type needs to be registered inside the gogoproto registry.
+func ExtractAnnotatedAddresses(msg types.Message) ([]string, error) {
+	msgAddresses, err := ExtractAnnotatedMessageAddresses(msg)
+	if err != nil {
+		return nil, err
+	}
+	return getAddresses(msgAddresses), nil
+}
+
+// ExtractAnnotatedMessageAddresses works like ExtractAnnotatedAddresses, but returns the role of each address as well.
+// Roles are determined based on the cosmos.msg.v1.signer option and on the fields names
+// (eg. from_address is a sender, while validator_address is a validator).
+func ExtractAnnotatedMessageAddresses(msg types.Message) ([]types.MessageAddress, error) {
+	var value interface{}
+	err := json.Unmarshal(msg.GetBytes(), &value)
+	if err != nil {
+		return nil, fmt.Errorf("error while unmarshalling message %s: %s", msg.GetType(), err)
+	}
+
+	msgAddresses, err := extractAnyAddresses(msg.GetType(), value, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return removeDuplicateMessageAddresses(msgAddresses), nil
+}
+
+// extractAnyAddresses extracts the addresses contained inside the given JSON value of the message having the given type
+func extractAnyAddresses(msgType string, value interface{}, depth int) ([]types.MessageAddress, error) {
+	fields, err := getAddressFields(strings.TrimPrefix(msgType, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	return extractAddresses(fields, value, depth, nil), nil
+}
+
+// extractAddresses extracts the addresses contained inside the given JSON value, based on the given fields.
+// The given parent roles are added to the roles of each field.
+func extractAddresses(fields []addressField, value interface{}, depth int, parentRoles []types.AddressRole) []types.MessageAddress {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var msgAddresses []types.MessageAddress
+	for _, field := range fields {
+		fieldValue, found := object[field.name]
+		if !found {
+			fieldValue = object[field.jsonName]
+		}
+
+		roles := append(append([]types.AddressRole{}, parentRoles...), field.roles...)
+
+		// Repeated fields are handled the same way as single values
+		values, isList := fieldValue.([]interface{})
+		if !isList {
+			values = []interface{}{fieldValue}
+		}
+
+		for _, v := range values {
+			switch {
+			case field.isAny:
+				anyValue, ok := v.(map[string]interface{})
+				if !ok || depth >= maxFieldsDepth {
+					continue
+				}
+				anyType, _ := anyValue["@type"].(string)
+				anyAddresses, _ := extractAnyAddresses(anyType, anyValue, depth+1)
+				msgAddresses = append(msgAddresses, anyAddresses...)
+
+			case field.nested != nil:
+				msgAddresses = append(msgAddresses, extractAddresses(field.nested, v, depth, roles)...)
+
+			default:
+				address, ok := v.(string)
+				if !ok || address == "" {
+					continue
+				}
+
+				if len(roles) == 0 {
+					msgAddresses = append(msgAddresses, types.NewMessageAddress(address, types.AddressRoleInvolved))
+				}
+				for _, role := range roles {
+					msgAddresses = append(msgAddresses, types.NewMessageAddress(address, role))
+				}
+			}
+		}
+	}
+	return msgAddresses
+}
+
+// getFieldRoles returns the roles of the addresses contained inside the field having the given name
+func getFieldRoles(name string, isSigner bool, isValidator bool) []types.AddressRole {
+	var roles []types.AddressRole
+	if isSigner {
+		roles = append(roles, types.AddressRoleSigner)
+	}
+
+	switch {
+	case strings.Contains(name, "granter"):
+		roles = append(roles, types.AddressRoleGranter)
+	case strings.Contains(name, "grantee"):
+		roles = append(roles, types.AddressRoleGrantee)
+	case isValidator || strings.Contains(name, "validator"):
+		roles = append(roles, types.AddressRoleValidator)
+	case strings.Contains(name, "sender") || strings.HasPrefix(name, "from") || strings.HasPrefix(name, "input"):
+		roles = append(roles, types.AddressRoleSender)
+	case strings.Contains(name, "recipient") || strings.Contains(name, "receiver") ||
+		strings.HasPrefix(name, "to_") || strings.HasPrefix(name, "output"):
+		roles = append(roles, types.AddressRoleRecipient)
+	}
+
+	return roles
+}
+
+// getAddressFields returns the address fields of the message having the given full name, caching the result
+func getAddressFields(msgName string) ([]addressField, error) {
+	addressFieldsMu.RLock()
+	fields, found := addressFieldsCache[msgName]
+	addressFieldsMu.RUnlock()
+	if found {
+		return fields, nil
+	}
+
+	fields, err := buildAddressFields(msgName, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	addressFieldsMu.Lock()
+	addressFieldsCache[msgName] = fields
+	addressFieldsMu.Unlock()
+
+	return fields, nil
+}
+
+// buildAddressFields builds the address fields of the message having the given full name
+func buildAddressFields(msgName string, depth int) ([]addressField, error) {
+	descriptor, err := getMessageDescriptor(msgName)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := map[string]bool{}
+	for _, signer := range getStringOptions(descriptor.GetOptions(), signerOptionNumber) {
+		signers[signer] = true
+	}
+
+	var fields []addressField
+	for _, field := range descriptor.GetField() {
+		switch field.GetType() {
+		case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+			var scalar string
+			if scalars := getStringOptions(field.GetOptions(), scalarOptionNumber); len(scalars) > 0 {
+				scalar = scalars[0]
+			}
+
+			if signers[field.GetName()] || addressScalars[scalar] {
+				fields = append(fields, addressField{
+					name:     field.GetName(),
+					jsonName: field.GetJsonName(),
+					roles:    getFieldRoles(field.GetName(), signers[field.GetName()], scalar == validatorAddressScalar),
+				})
+			}
+
+		case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+			typeName := strings.TrimPrefix(field.GetTypeName(), ".")
+			if typeName == "google.protobuf.Any" {
+				fields = append(fields, addressField{name: field.GetName(), jsonName: field.GetJsonName(), isAny: true})
+				continue
+			}
+
+			if depth >= maxFieldsDepth {
+				continue
+			}
+
+			nested, err := buildAddressFields(typeName, depth+1)
+			if err != nil || len(nested) == 0 {
+				continue
+			}
+			fields = append(fields, addressField{
+				name:     field.GetName(),
+				jsonName: field.GetJsonName(),
+				roles:    getFieldRoles(field.GetName(), signers[field.GetName()], false),
+				nested:   nested,
+			})
+		}
+	}
+
+	return fields, nil
+}
+
+// descriptorMessage represents a gogoproto message that exposes its own file descriptor
+type descriptorMessage interface {
+	Descriptor() ([]byte, []int)
+}
+
+// getMessageDescriptor returns the descriptor of the message having the given full name, reading it from the
+// gogoproto registry. Options are kept as unknown fields, so that they can be read regardless of whether the
+// packages defining them have been imported.
+func getMessageDescriptor(msgName string) (*descriptorpb.DescriptorProto, error) {
+	msgType := gogoproto.MessageType(msgName)
+	if msgType == nil {
+		return nil, fmt.Errorf("message %s is not registered", msgName)
+	}
+
+	if msgType.Kind() == reflect.Ptr {
+		msgType = msgType.Elem()
+	}
+
+	msg, ok := reflect.New(msgType).Interface().(descriptorMessage)
+	if !ok {
+		return nil, fmt.Errorf("message %s does not expose its descriptor", msgName)
+	}
+
+	gzipped, path := msg.Descriptor()
+	reader, err := gzip.NewReader(bytes.NewReader(gzipped))
+	if err != nil {
+		return nil, fmt.Errorf("error while reading descriptor of message %s: %s", msgName, err)
+	}
+
+	bz, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading descriptor of message %s: %s", msgName, err)
+	}
+
+	var file descriptorpb.FileDescriptorProto
+	err = proto.UnmarshalOptions{Resolver: &protoregistry.Types{}}.Unmarshal(bz, &file)
+	if err != nil {
+		return nil, fmt.Errorf("error while unmarshalling descriptor of message %s: %s", msgName, err)
+	}
+
+	if len(path) == 0 || path[0] >= len(file.MessageType) {
+		return nil, fmt.Errorf("invalid descriptor path of message %s", msgName)
+	}
+
+	descriptor := file.MessageType[path[0]]
+	for _, index := range path[1:] {
+		if index >= len(descriptor.NestedType) {
+			return nil, fmt.Errorf("invalid descriptor path of message %s", msgName)
+		}
+		descriptor = descriptor.NestedType[index]
+	}
+
+	return descriptor, nil
+}
+
+// getStringOptions returns the string values of the option having the given number.
+// The option is read from the unknown fields of the given options message.
+func getStringOptions(options proto.Message, number protowire.Number) []string {
+	if options == nil || reflect.ValueOf(options).IsNil() {
+		return nil
+	}
+
+	var values []string
+	bz := options.ProtoReflect().GetUnknown()
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return values
+		}
+		bz = bz[n:]
+
+		if num == number && typ == protowire.BytesType {
+			value, n := protowire.ConsumeBytes(bz)
+			if n < 0 {
+				return values
+			}
+			values = append(values, string(value))
+			bz = bz[n:]
+			continue
+		}
+
+		n = protowire.ConsumeFieldValue(num, typ, bz)
+		if n < 0 {
+			return values
+		}
+		bz = bz[n:]
+	}
+	return values
+}
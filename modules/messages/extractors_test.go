@@ -0,0 +1,153 @@
+package messages_test
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	// Register the messages types used inside the tests
+	_ "github.com/cosmos/cosmos-sdk/x/authz"
+	_ "github.com/cosmos/cosmos-sdk/x/bank/types"
+	_ "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/forbole/juno/v5/modules/messages"
+	"github.com/forbole/juno/v5/types"
+)
+
+func TestParseMessageAddresses(t *testing.T) {
+	sender := sdk.AccAddress("sender______________").String()
+	receiver := sdk.AccAddress("receiver____________").String()
+	grantee := sdk.AccAddress("grantee_____________").String()
+	unrelated := sdk.AccAddress("unrelated___________").String()
+
+	// Events of other messages should be ignored
+	tx := &types.Transaction{
+		TxResponse: &types.TxResponse{TxResponse: &sdk.TxResponse{
+			Logs: sdk.ABCIMessageLogs{
+				{MsgIndex: 0},
+				{MsgIndex: 1, Events: sdk.StringEvents{{
+					Type:       "transfer",
+					Attributes: []sdk.Attribute{{Key: "recipient", Value: unrelated}},
+				}}},
+			},
+		}},
+	}
+
+	testCases := []struct {
+		name      string
+		msg       types.Message
+		addresses []string
+	}{
+		{
+			name: "annotated fields are returned",
+			msg: types.NewStandardMessage(0, "/cosmos.bank.v1beta1.MsgSend", []byte(fmt.Sprintf(
+				`{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%s","to_address":"%s","amount":[]}`,
+				sender, receiver,
+			))),
+			addresses: []string{sender, receiver},
+		},
+		{
+			name: "nested fields are returned",
+			msg: types.NewStandardMessage(0, "/cosmos.bank.v1beta1.MsgMultiSend", []byte(fmt.Sprintf(
+				`{"@type":"/cosmos.bank.v1beta1.MsgMultiSend","inputs":[{"address":"%s"}],"outputs":[{"address":"%s"}]}`,
+				sender, receiver,
+			))),
+			addresses: []string{sender, receiver},
+		},
+		{
+			name: "messages inside any fields are returned",
+			msg: types.NewStandardMessage(0, "/cosmos.authz.v1beta1.MsgExec", []byte(fmt.Sprintf(
+				`{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"%s","msgs":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%s","to_address":"%s"}]}`,
+				grantee, sender, receiver,
+			))),
+			addresses: []string{grantee, sender, receiver},
+		},
+		{
+			name:      "unknown messages fall back to their own events",
+			msg:       types.NewStandardMessage(1, "/unknown.v1.MsgUnknown", []byte(`{"@type":"/unknown.v1.MsgUnknown"}`)),
+			addresses: []string{unrelated},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			addresses, err := messages.ParseMessageAddresses(tc.msg, tx)
+			require.NoError(t, err)
+			require.Equal(t, tc.addresses, addresses)
+		})
+	}
+}
+
+func TestRegisterMessageAddressesExtractor(t *testing.T) {
+	messages.RegisterMessageAddressesExtractor("/custom.v1.MsgCustom", func(msg types.Message, _ *types.Transaction) ([]string, error) {
+		return []string{"custom", "custom"}, nil
+	})
+
+	msg := types.NewStandardMessage(0, "/custom.v1.MsgCustom", []byte(`{"@type":"/custom.v1.MsgCustom"}`))
+	addresses, err := messages.ParseMessageAddresses(msg, &types.Transaction{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"custom"}, addresses)
+}
+
+func TestAddressCodec_ParseMessageAddressesWithRoles(t *testing.T) {
+	sender := sdk.AccAddress("sender______________").String()
+	receiver := sdk.AccAddress("receiver____________").String()
+	grantee := sdk.AccAddress("grantee_____________").String()
+	validator := sdk.ValAddress("validator___________").String()
+
+	testCases := []struct {
+		name      string
+		msg       types.Message
+		addresses []types.MessageAddress
+	}{
+		{
+			name: "senders and recipients are labelled",
+			msg: types.NewStandardMessage(0, "/cosmos.bank.v1beta1.MsgMultiSend", []byte(fmt.Sprintf(
+				`{"@type":"/cosmos.bank.v1beta1.MsgMultiSend","inputs":[{"address":"%s"}],"outputs":[{"address":"%s"}]}`,
+				sender, receiver,
+			))),
+			addresses: []types.MessageAddress{
+				types.NewMessageAddress(sender, types.AddressRoleSigner),
+				types.NewMessageAddress(sender, types.AddressRoleSender),
+				types.NewMessageAddress(receiver, types.AddressRoleRecipient),
+			},
+		},
+		{
+			name: "validators are labelled",
+			msg: types.NewStandardMessage(0, "/cosmos.staking.v1beta1.MsgDelegate", []byte(fmt.Sprintf(
+				`{"@type":"/cosmos.staking.v1beta1.MsgDelegate","delegator_address":"%s","validator_address":"%s"}`,
+				sender, validator,
+			))),
+			addresses: []types.MessageAddress{
+				types.NewMessageAddress(sender, types.AddressRoleSigner),
+				types.NewMessageAddress(validator, types.AddressRoleValidator),
+			},
+		},
+		{
+			name: "grantees are labelled",
+			msg: types.NewStandardMessage(0, "/cosmos.authz.v1beta1.MsgExec", []byte(fmt.Sprintf(
+				`{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"%s","msgs":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%s","to_address":"%s"}]}`,
+				grantee, sender, receiver,
+			))),
+			addresses: []types.MessageAddress{
+				types.NewMessageAddress(grantee, types.AddressRoleSigner),
+				types.NewMessageAddress(grantee, types.AddressRoleGrantee),
+				types.NewMessageAddress(sender, types.AddressRoleSigner),
+				types.NewMessageAddress(sender, types.AddressRoleSender),
+				types.NewMessageAddress(receiver, types.AddressRoleRecipient),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			addresses, err := messages.DefaultAddressCodec().ParseMessageAddressesWithRoles(tc.msg, &types.Transaction{})
+			require.NoError(t, err)
+			require.Equal(t, tc.addresses, addresses)
+		})
+	}
+}
@@ -1,21 +1,89 @@
 package messages
 
 import (
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/forbole/juno/v5/database"
+	"github.com/forbole/juno/v5/logging"
 	"github.com/forbole/juno/v5/types"
 )
 
-// HandleMsg represents a message handler that stores the given message inside the proper database table
+// HandleMsg represents a message handler that stores the given message inside the proper database table.
+// The involved addresses are the ones returned by the given parser, merged with the ones obtained using
+// AddressCodec.ParseMessageAddressesWithRoles. They are stored in their canonical form obtained using the codec and,
+// if the database supports it, in their raw form as well.
+// If the database supports it, the message is also indexed by the canonical form of each address and its role.
 func HandleMsg(
 	index int, msg types.Message, tx *types.Transaction,
-	parseAddresses MessageAddressesParser, db database.Database,
+	parseAddresses MessageAddressesParser, codec *AddressCodec, db database.Database,
 ) error {
 
-	// Get the involved addresses
-	addresses, err := parseAddresses(tx)
+	// Get the addresses involved by this message only
+	msgAddresses, err := codec.ParseMessageAddressesWithRoles(msg, tx)
+	if err != nil {
+		return err
+	}
+
+	// Add the addresses returned by the parser (eg. a custom one handling the chain specific messages)
+	parsedAddresses, err := parseAddresses(getMessageTx(msg, tx))
+	if err != nil {
+		return err
+	}
+	msgAddresses = mergeMessageAddresses(msgAddresses, withRole(parsedAddresses, types.AddressRoleInvolved))
+
+	rawAddresses := getAddresses(msgAddresses)
+	timer := prometheus.NewTimer(logging.DbWriteDuration.WithLabelValues("save_message"))
+	if rawAddressesDb, ok := db.(database.RawAddressesMessageDb); ok {
+		err = rawAddressesDb.SaveMessageWithRawAddresses(int64(tx.Height), tx.TxHash, msg, codec.NormalizeAll(rawAddresses), rawAddresses)
+	} else {
+		err = db.SaveMessage(int64(tx.Height), tx.TxHash, msg, codec.NormalizeAll(rawAddresses))
+	}
+	timer.ObserveDuration()
 	if err != nil {
 		return err
 	}
 
-	return db.SaveMessage(int64(tx.Height), tx.TxHash, msg, addresses)
+	if addressMessageDb, ok := db.(database.AddressMessageDb); ok {
+		timer = prometheus.NewTimer(logging.DbWriteDuration.WithLabelValues("save_address_messages"))
+		defer timer.ObserveDuration()
+		return addressMessageDb.SaveAddressMessages(int64(tx.Height), tx.TxHash, index, codec.normalizeMessageAddresses(msgAddresses))
+	}
+
+	return nil
+}
+
+// getMessageTx returns a copy of the given transaction whose body only contains the given message, so that
+// a MessageAddressesParser returns only the addresses involved by such message
+func getMessageTx(msg types.Message, tx *types.Transaction) *types.Transaction {
+	if tx.Tx == nil || tx.Body == nil {
+		return tx
+	}
+
+	body := *tx.Body
+	body.Messages = []types.Message{msg}
+
+	msgTx := *tx.Tx
+	msgTx.Body = &body
+
+	return &types.Transaction{
+		TxResponse: tx.TxResponse,
+		Tx:         &msgTx,
+	}
+}
+
+// mergeMessageAddresses returns the given message addresses along with the additional ones
+// whose addresses are not already present among them
+func mergeMessageAddresses(msgAddresses []types.MessageAddress, additional []types.MessageAddress) []types.MessageAddress {
+	present := make(map[string]bool, len(msgAddresses))
+	for _, msgAddress := range msgAddresses {
+		present[msgAddress.Address] = true
+	}
+
+	for _, msgAddress := range additional {
+		if !present[msgAddress.Address] {
+			present[msgAddress.Address] = true
+			msgAddresses = append(msgAddresses, msgAddress)
+		}
+	}
+	return msgAddresses
 }
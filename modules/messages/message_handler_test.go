@@ -0,0 +1,126 @@
+package messages_test
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/database"
+	"github.com/forbole/juno/v5/modules/messages"
+	"github.com/forbole/juno/v5/types"
+)
+
+// mockDb represents a database.RawAddressesMessageDb and database.AddressMessageDb implementation
+// that records the stored data
+type mockDb struct {
+	database.Database
+
+	addresses        []string
+	rawAddresses     []string
+	addressMessages  []types.MessageAddress
+	addressMsgHeight int64
+}
+
+func (db *mockDb) SaveMessageWithRawAddresses(
+	_ int64, _ string, _ types.Message, addresses []string, rawAddresses []string,
+) error {
+	db.addresses = addresses
+	db.rawAddresses = rawAddresses
+	return nil
+}
+
+func (db *mockDb) SaveAddressMessages(height int64, _ string, _ int, addresses []types.MessageAddress) error {
+	db.addressMsgHeight = height
+	db.addressMessages = addresses
+	return nil
+}
+
+func (db *mockDb) GetAddressMessages(_ string, _ []types.AddressRole, _, _ int64) ([]types.AddressMessage, error) {
+	return nil, nil
+}
+
+// basicDb represents a database.Database implementation that only supports storing the canonical addresses
+type basicDb struct {
+	database.Database
+
+	addresses []string
+}
+
+func (db *basicDb) SaveMessage(_ int64, _ string, _ types.Message, addresses []string) error {
+	db.addresses = addresses
+	return nil
+}
+
+func TestHandleMsg(t *testing.T) {
+	sender := sdk.AccAddress("sender______________").String()
+	receiverBz := []byte("receiver____________")
+	receiver := sdk.AccAddress(receiverBz).String()
+	osmoReceiver, err := bech32.ConvertAndEncode("osmo", receiverBz)
+	require.NoError(t, err)
+
+	msg := types.NewStandardMessage(0, "/cosmos.bank.v1beta1.MsgSend", []byte(fmt.Sprintf(
+		`{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%s","to_address":"%s"}`,
+		sender, osmoReceiver,
+	)))
+	tx := &types.Transaction{TxResponse: &types.TxResponse{TxResponse: &sdk.TxResponse{TxHash: "hash"}, Height: 10}}
+
+	db := &mockDb{}
+	codec := messages.NewAddressCodec([]string{"osmo"}, false)
+	err = messages.HandleMsg(0, msg, tx, messages.DefaultMessagesParser, codec, db)
+	require.NoError(t, err)
+
+	// Both the canonical and raw addresses should be stored
+	require.Equal(t, []string{sender, receiver}, db.addresses)
+	require.Equal(t, []string{sender, osmoReceiver}, db.rawAddresses)
+
+	// The message should be indexed by the canonical addresses
+	require.Equal(t, int64(10), db.addressMsgHeight)
+	require.Equal(t, []types.MessageAddress{
+		types.NewMessageAddress(sender, types.AddressRoleSigner),
+		types.NewMessageAddress(sender, types.AddressRoleSender),
+		types.NewMessageAddress(receiver, types.AddressRoleRecipient),
+	}, db.addressMessages)
+
+	// Databases not supporting the raw addresses should store the canonical ones
+	basic := &basicDb{}
+	err = messages.HandleMsg(0, msg, tx, messages.DefaultMessagesParser, codec, basic)
+	require.NoError(t, err)
+	require.Equal(t, []string{sender, receiver}, basic.addresses)
+}
+
+func TestHandleMsg_CustomParser(t *testing.T) {
+	first := sdk.AccAddress("first_______________").String()
+	second := sdk.AccAddress("second______________").String()
+
+	// The custom parser returns the addresses of the custom messages contained inside the given tx
+	customAddresses := map[string]string{"/custom.v1.MsgFirst": first, "/custom.v1.MsgSecond": second}
+	parser := func(tx *types.Transaction) ([]string, error) {
+		var addresses []string
+		for _, msg := range tx.Body.Messages {
+			if address, ok := customAddresses[msg.GetType()]; ok {
+				addresses = append(addresses, address)
+			}
+		}
+		return addresses, nil
+	}
+
+	firstMsg := types.NewStandardMessage(0, "/custom.v1.MsgFirst", []byte(`{"@type":"/custom.v1.MsgFirst"}`))
+	secondMsg := types.NewStandardMessage(1, "/custom.v1.MsgSecond", []byte(`{"@type":"/custom.v1.MsgSecond"}`))
+	tx := &types.Transaction{
+		TxResponse: &types.TxResponse{TxResponse: &sdk.TxResponse{TxHash: "hash"}, Height: 10},
+		Tx:         &types.Tx{Body: &types.TxBody{Messages: []types.Message{firstMsg, secondMsg}}},
+	}
+
+	db := &mockDb{}
+	err := messages.HandleMsg(1, secondMsg, tx, messages.JoinMessageParsers(parser), messages.DefaultAddressCodec(), db)
+	require.NoError(t, err)
+
+	// Only the addresses of the handled message should be stored
+	require.Equal(t, []string{second}, db.addresses)
+	require.Equal(t, []types.MessageAddress{
+		types.NewMessageAddress(second, types.AddressRoleInvolved),
+	}, db.addressMessages)
+}
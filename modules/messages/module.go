@@ -1,33 +1,88 @@
 package messages
 
 import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
 	"github.com/forbole/juno/v5/database"
 	"github.com/forbole/juno/v5/modules"
 	"github.com/forbole/juno/v5/types"
+	"github.com/forbole/juno/v5/types/config"
 )
 
-var _ modules.Module = &Module{}
+const (
+	ModuleName = "messages"
+)
+
+var (
+	_ modules.Module              = &Module{}
+	_ modules.InitializableModule = &Module{}
+	_ modules.MessageModule       = &Module{}
+)
 
 // Module represents the module allowing to store messages properly inside a dedicated table
 type Module struct {
+	junoCfg config.Config
+	cfg     *Config
+
 	parser MessageAddressesParser
+	codec  *AddressCodec
+	cdc    codec.Codec
 
 	db database.Database
 }
 
+// NewModule returns a new Module instance
 func NewModule(parser MessageAddressesParser, db database.Database) *Module {
 	return &Module{
+		cfg:    DefaultConfig(),
 		parser: parser,
+		codec:  DefaultAddressCodec(),
 		db:     db,
 	}
 }
 
+// WithConfig sets the Juno configuration from which the module configuration is read.
+// The module configuration is parsed and validated when calling Init. If no configuration is set,
+// the default one is used instead.
+func (m *Module) WithConfig(cfg config.Config) *Module {
+	m.junoCfg = cfg
+	return m
+}
+
+// WithCodec sets the codec used to decode the messages, if enabled inside the configuration
+func (m *Module) WithCodec(cdc codec.Codec) *Module {
+	m.cdc = cdc
+	return m
+}
+
 // Name implements modules.Module
 func (m *Module) Name() string {
-	return "messages"
+	return ModuleName
+}
+
+// Init implements modules.InitializableModule
+func (m *Module) Init() error {
+	cfg, err := m.junoCfg.GetModuleConfig(ModuleName)
+	if err != nil {
+		return err
+	}
+
+	m.cfg = cfg.(*Config)
+	m.codec = NewAddressCodec(m.cfg.AddressPrefixes, m.cfg.HexAddresses)
+
+	if m.cfg.DecodeMessages && m.cdc == nil {
+		return fmt.Errorf("cannot decode messages without a codec")
+	}
+
+	return nil
 }
 
 // HandleMsg implements modules.MessageModule
 func (m *Module) HandleMsg(index int, msg types.Message, tx *types.Transaction) error {
-	return HandleMsg(index, msg, tx, m.parser, m.db)
+	if m.cfg.DecodeMessages {
+		msg = DecodeMessage(m.cdc, msg)
+	}
+	return HandleMsg(index, msg, tx, m.parser, m.codec, m.db)
 }
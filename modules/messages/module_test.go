@@ -0,0 +1,28 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/modules/messages"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+func TestModule_Init(t *testing.T) {
+	// Without any configuration the default one should be used
+	module := messages.NewModule(messages.DefaultMessagesParser, nil)
+	require.NoError(t, module.Init())
+
+	junoCfg, err := config.DefaultConfigParser([]byte(`
+chain:
+  modules: [ messages ]
+messages:
+  decode_messages: true
+`))
+	require.NoError(t, err)
+
+	// Decoding the messages requires a codec
+	module = messages.NewModule(messages.DefaultMessagesParser, nil).WithConfig(junoCfg)
+	require.Error(t, module.Init())
+}
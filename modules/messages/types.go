@@ -1,8 +1,6 @@
 package messages
 
 import (
-	sdk "github.com/cosmos/cosmos-sdk/types"
-
 	"github.com/forbole/juno/v5/types"
 )
 
@@ -14,10 +12,28 @@ type MessageAddressesParser = func(tx *types.Transaction) ([]string, error)
 // Chain message and returns all the involved addresses (both accounts and validators)
 var CosmosMessageAddressesParser = DefaultMessagesParser
 
-// DefaultMessagesParser represents the default messages parser that simply returns the list
-// of all the signers of a message
+// DefaultMessagesParser represents the default messages parser that returns the addresses
+// involved by all the messages of the transaction, obtained using ParseMessageAddresses
 func DefaultMessagesParser(tx *types.Transaction) ([]string, error) {
-	return parseAddressesFromEvents(tx), nil
+	return DefaultAddressCodec().ParseTxAddresses(tx)
+}
+
+// ParseTxAddresses returns the raw addresses involved by all the messages of the given transaction
+func (c *AddressCodec) ParseTxAddresses(tx *types.Transaction) ([]string, error) {
+	if tx.Tx == nil || tx.Body == nil {
+		return c.parseAddressesFromEvents(tx), nil
+	}
+
+	addresses := []string{}
+	for _, msg := range tx.Body.Messages {
+		msgAddresses, err := c.ParseMessageAddresses(msg, tx)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, msgAddresses...)
+	}
+
+	return removeDuplicates(addresses), nil
 }
 
 // function to remove duplicate values
@@ -33,24 +49,42 @@ func removeDuplicates(s []string) []string {
 	return result
 }
 
-func parseAddressesFromEvents(tx *types.Transaction) []string {
-	addresses := []string{}
+// parseAddressesFromEvents returns all the addresses contained inside the attributes of the given tx events
+func (c *AddressCodec) parseAddressesFromEvents(tx *types.Transaction) []string {
+	var values []string
 	for _, event := range tx.Events {
 		for _, attribute := range event.Attributes {
-			// Try parsing the address as a validator address
-			validatorAddress, _ := sdk.ValAddressFromBech32(attribute.Value)
-			if validatorAddress != nil {
-				addresses = append(addresses, validatorAddress.String())
-			}
-
-			// Try parsing the address as an account address
-			accountAddress, err := sdk.AccAddressFromBech32(attribute.Value)
-			if err != nil {
-				// Skip if the address is not an account address
-				continue
-			}
-
-			addresses = append(addresses, accountAddress.String())
+			values = append(values, attribute.Value)
+		}
+	}
+
+	return c.parseAddressesFromValues(values)
+}
+
+// parseMessageAddressesFromEvents returns all the addresses contained inside the attributes of the events
+// emitted by the message having the given index. If the tx logs are not available (eg. the tx failed),
+// the attributes of all the tx events are used instead.
+func (c *AddressCodec) parseMessageAddressesFromEvents(index int, tx *types.Transaction) []string {
+	if tx.TxResponse == nil || tx.TxResponse.TxResponse == nil || index >= len(tx.Logs) {
+		return c.parseAddressesFromEvents(tx)
+	}
+
+	var values []string
+	for _, event := range tx.Logs[index].Events {
+		for _, attribute := range event.Attributes {
+			values = append(values, attribute.Value)
+		}
+	}
+
+	return c.parseAddressesFromValues(values)
+}
+
+// parseAddressesFromValues returns all the given values that are recognized as addresses by the codec
+func (c *AddressCodec) parseAddressesFromValues(values []string) []string {
+	addresses := []string{}
+	for _, value := range values {
+		if _, ok := c.Normalize(value); ok {
+			addresses = append(addresses, value)
 		}
 	}
 
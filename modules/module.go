@@ -6,11 +6,13 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/x/authz"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
 	tmtypes "github.com/cometbft/cometbft/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/go-co-op/gocron"
 
+	"github.com/forbole/juno/v5/node"
 	"github.com/forbole/juno/v5/types"
 )
 
@@ -34,8 +36,100 @@ func (m Modules) FindByName(name string) (module Module, found bool) {
 	return nil, false
 }
 
+// Names returns the names of all the modules inside the m slice, in order
+func (m Modules) Names() []string {
+	names := make([]string, len(m))
+	for i, module := range m {
+		names[i] = module.Name()
+	}
+	return names
+}
+
 // --------------------------------------------------------------------------------------------------------------------
 
+type DependentModule interface {
+	// DependsOn returns the names of the modules that must be enabled and whose handlers must be called
+	// before the ones of this module (eg. because this module reads their database tables).
+	// NOTE. Modules are sorted based on their dependencies when starting, and any missing dependency or
+	// dependency cycle will cause the startup to fail.
+	DependsOn() []string
+}
+
+type InitializableModule interface {
+	// Init validates the module configuration and prepares the module to be used.
+	// NOTE. This method will only be run ONCE before any other method of the module, and any returned error
+	// will stop Juno from starting.
+	Init() error
+}
+
+type StartableModule interface {
+	// Start starts all the background resources of the module (eg. servers or connections).
+	// NOTE. This method will only be run ONCE after all the modules have been initialized and before starting the
+	// parsing of the blocks. Any returned error will stop Juno from starting.
+	Start() error
+}
+
+type StoppableModule interface {
+	// Stop releases all the resources used by the module.
+	// NOTE. This method will only be run ONCE when Juno is shutting down. Any returned error will be logged,
+	// and all other modules will still be stopped.
+	Stop() error
+}
+
+type HealthCheckModule interface {
+	// HealthCheck returns an error if the module is not able to work properly (eg. a required external
+	// service is not reachable), or nil if it is healthy.
+	HealthCheck() error
+}
+
+type ModulesAwareModule interface {
+	// SetModules provides the module with all the enabled modules, sorted based on their dependencies.
+	// NOTE. This method will only be run ONCE before calling Init.
+	SetModules(mods []Module)
+}
+
+type ParsingStatusAwareModule interface {
+	// SetParsingStatus provides the module with the status of the parsing, which is updated by the workers
+	// while parsing the blocks.
+	// NOTE. This method will only be run ONCE before calling Start.
+	SetParsingStatus(status *types.ParsingStatus)
+}
+
+// HeightDb represents the subset of the database.Database methods available to the DatabaseAwareModule
+// implementations. The database.Database interface cannot be used here since the database package depends
+// on this one.
+type HeightDb interface {
+	// GetLastBlockHeight returns the last block height stored inside the database
+	GetLastBlockHeight() (int64, error)
+}
+
+type DatabaseAwareModule interface {
+	// SetDatabase provides the module with the database used to store the parsed data.
+	// NOTE. This method will only be run ONCE before calling Start.
+	SetDatabase(db HeightDb)
+}
+
+type NodeAwareModule interface {
+	// SetNode provides the module with the node used to get the chain data.
+	// NOTE. This method will only be run ONCE before calling Start.
+	SetNode(node node.Node)
+}
+
+type PrunableModule interface {
+	// Prune deletes all the data that the module has stored for the heights within [fromHeight, toHeight).
+	// NOTE. This method is called by the pruning module only if a retention policy has been configured for
+	// the module, and it might be called multiple times for the same heights (eg. after a crash).
+	// Since pruning runs in background, this method might be called concurrently with the other handlers.
+	Prune(fromHeight, toHeight int64) error
+}
+
+type BlocksPruningModule interface {
+	// GetBlocksPrunedHeight returns the height before which the blocks have been pruned, or 0 if they are never pruned.
+	// NOTE. Juno never parses again the missing blocks having a lower height, so that they are not stored again
+	// after being pruned.
+	GetBlocksPrunedHeight() (int64, error)
+}
+
 type AdditionalOperationsModule interface {
 	// RunAdditionalOperations runs all the additional operations required by the module.
 	// This is the perfect place where to initialize all the operations that subscribe to websockets or other
@@ -100,6 +194,41 @@ type MessageModule interface {
 	HandleMsg(index int, msg types.Message, tx *types.Transaction) error
 }
 
+// EventSource represents the source of an ABCI event
+type EventSource string
+
+const (
+	EventSourceBeginBlock EventSource = "begin_block"
+	EventSourceEndBlock   EventSource = "end_block"
+	EventSourceTx         EventSource = "tx"
+)
+
+type EventModule interface {
+	// EventTypes returns the types of the events (eg. "slash" or "withdraw_rewards") that the module handles.
+	// HandleEvent will only be called for events having one of these types. If empty, HandleEvent will be
+	// called for all the events.
+	EventTypes() []string
+
+	// HandleEvent handles a single ABCI event emitted at the given height.
+	// The source tells whether the event has been emitted during the begin block, the end block or
+	// the execution of a transaction. In the latter case, the hash of the transaction is passed as well.
+	// NOTE. The returned error will be logged using the EventsError method. All other modules' handlers
+	// will still be called.
+	HandleEvent(height int64, source EventSource, txHash string, event abci.Event) error
+}
+
+type MessageFilter interface {
+	// MessageTypes returns the type URLs (eg. "/cosmos.bank.v1beta1.MsgSend") of the messages that the module
+	// handles. If the module implements MessageModule, HandleMsg will only be called for messages having
+	// one of these types. If empty, HandleMsg will be called for all the messages.
+	MessageTypes() []string
+
+	// TxEventTypes returns the types of the events (eg. "transfer") that the module is interested in.
+	// If the module implements TransactionModule, HandleTx will only be called for transactions that have
+	// emitted at least one event having one of these types. If empty, HandleTx will be called for all the transactions.
+	TxEventTypes() []string
+}
+
 type AuthzMessageModule interface {
 	// HandleMsgExec handles a single message that is contained within an authz.MsgExec instance.
 	// For convenience of use, the index of the message inside the transaction and the transaction itself
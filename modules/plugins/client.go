@@ -0,0 +1,145 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/forbole/juno/v5/modules/progress"
+)
+
+// client represents the connection to a single plugin
+type client struct {
+	cfg     PluginConfig
+	tracker *progress.Tracker
+
+	cmd  *exec.Cmd
+	conn *grpc.ClientConn
+
+	// messageTypes contains the message types the plugin handles, or nil if it handles all of them
+	messageTypes map[string]bool
+}
+
+// newClient returns a new client for the plugin having the given configuration,
+// which keeps track of the delivered heights using the given tracker
+func newClient(cfg PluginConfig, tracker *progress.Tracker) *client {
+	return &client{
+		cfg:     cfg,
+		tracker: tracker,
+	}
+}
+
+// start launches the plugin process (if needed), connects to it and reads its details.
+// If any of these steps fail, the plugin process is stopped.
+func (c *client) start() (err error) {
+	defer func() {
+		if err != nil {
+			_ = c.stop()
+		}
+	}()
+
+	if c.cfg.Command != "" {
+		c.cmd = exec.Command(c.cfg.Command, c.cfg.Args...)
+		c.cmd.Stdout = os.Stdout
+		c.cmd.Stderr = os.Stderr
+		err = c.cmd.Start()
+		if err != nil {
+			return fmt.Errorf("error while launching plugin process: %s", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.GetTimeout())
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, c.cfg.Address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(CodecName)),
+	)
+	if err != nil {
+		return fmt.Errorf("error while connecting to plugin: %s", err)
+	}
+	c.conn = conn
+
+	// Wait for the plugin to be ready, and get its details
+	var info InfoResponse
+	err = c.conn.Invoke(ctx, methodInfo, &InfoRequest{}, &info, grpc.WaitForReady(true))
+	if err != nil {
+		return fmt.Errorf("error while getting plugin info: %s", err)
+	}
+
+	if len(info.MessageTypes) > 0 {
+		c.messageTypes = map[string]bool{}
+		for _, msgType := range info.MessageTypes {
+			c.messageTypes[msgType] = true
+		}
+	}
+
+	return nil
+}
+
+// handlesMessage tells whether the plugin handles the messages having the given type
+func (c *client) handlesMessage(msgType string) bool {
+	return c.messageTypes == nil || c.messageTypes[msgType]
+}
+
+// call invokes the given method of the plugin, returning an error if the plugin does not acknowledge it
+func (c *client) call(method string, req interface{}) error {
+	if c.conn == nil {
+		return fmt.Errorf("plugin is not connected")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.GetTimeout())
+	defer cancel()
+
+	var ack Ack
+	err := c.conn.Invoke(ctx, method, req, &ack)
+	if err != nil {
+		return err
+	}
+
+	if ack.Error != "" {
+		return fmt.Errorf("%s", ack.Error)
+	}
+
+	return nil
+}
+
+// healthCheck returns an error if the plugin is not reachable
+func (c *client) healthCheck() error {
+	if c.conn == nil {
+		return fmt.Errorf("plugin is not connected")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.GetTimeout())
+	defer cancel()
+
+	var info InfoResponse
+	return c.conn.Invoke(ctx, methodInfo, &InfoRequest{}, &info)
+}
+
+// stop closes the connection to the plugin, and stops the plugin process if it has been launched by Juno.
+// Calling it more than once has no effect.
+func (c *client) stop() error {
+	if c.conn != nil {
+		err := c.conn.Close()
+		c.conn = nil
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.cmd != nil && c.cmd.Process != nil {
+		err := c.cmd.Process.Kill()
+		if err != nil {
+			return err
+		}
+		_ = c.cmd.Wait()
+		c.cmd = nil
+	}
+
+	return nil
+}
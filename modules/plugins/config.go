@@ -0,0 +1,95 @@
+package plugins
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/forbole/juno/v5/types/config"
+)
+
+var (
+	_ config.ModuleConfig = &Config{}
+)
+
+func init() {
+	config.RegisterModuleConfig(ModuleName, "plugins", func() config.ModuleConfig {
+		return DefaultConfig()
+	})
+}
+
+// Config represents the configuration of the plugins module
+type Config struct {
+	Plugins []PluginConfig `yaml:"plugins"`
+
+	// RedeliveryInterval represents the interval at which the heights that have not been acknowledged are sent again
+	RedeliveryInterval time.Duration `yaml:"redelivery_interval"`
+}
+
+// DefaultConfig returns the default Config instance
+func DefaultConfig() *Config {
+	return &Config{
+		RedeliveryInterval: time.Minute,
+	}
+}
+
+// Validate implements config.ModuleConfig
+func (c *Config) Validate() error {
+	if c.RedeliveryInterval <= 0 {
+		return fmt.Errorf("redelivery_interval must be greater than 0")
+	}
+
+	names := map[string]bool{}
+	for _, plugin := range c.Plugins {
+		err := plugin.Validate()
+		if err != nil {
+			return err
+		}
+
+		if names[plugin.Name] {
+			return fmt.Errorf("duplicated plugin name: %s", plugin.Name)
+		}
+		names[plugin.Name] = true
+	}
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// PluginConfig contains the configuration of a single plugin
+type PluginConfig struct {
+	// Name is used to identify the plugin inside the logs and to store its progress
+	Name string `yaml:"name"`
+
+	// Address represents the gRPC address (eg. localhost:9999) on which the plugin process listens
+	Address string `yaml:"address"`
+
+	// Command, if set, is run when starting Juno to launch the plugin process, which is then killed when
+	// Juno stops. If empty, the plugin process is supposed to be managed externally.
+	Command string   `yaml:"command,omitempty"`
+	Args    []string `yaml:"args,omitempty"`
+
+	// Timeout represents the max time that the plugin can take to acknowledge a single callback.
+	// Any value less or equal to 0 means to use the default timeout.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// Validate returns an error if the plugin configuration is not valid
+func (c PluginConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("plugin name cannot be empty")
+	}
+
+	if c.Address == "" {
+		return fmt.Errorf("address of plugin %s cannot be empty", c.Name)
+	}
+
+	return nil
+}
+
+// GetTimeout returns the max time that the plugin can take to acknowledge a single callback
+func (c PluginConfig) GetTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultTimeout
+	}
+	return c.Timeout
+}
@@ -0,0 +1,215 @@
+package plugins
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	tmjson "github.com/cometbft/cometbft/libs/json"
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/forbole/juno/v5/types"
+)
+
+// HandleGenesis implements modules.GenesisModule
+func (m *Module) HandleGenesis(doc *tmtypes.GenesisDoc, appState map[string]json.RawMessage) error {
+	genesisBz, err := tmjson.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("error while serializing genesis: %s", err)
+	}
+
+	req := &GenesisRequest{
+		Genesis:  genesisBz,
+		AppState: appState,
+	}
+
+	var errs []error
+	for _, client := range m.clients {
+		err = client.call(methodHandleGenesis, req)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("plugin %s: %s", client.cfg.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HandleBlock implements modules.BlockModule.
+// The block, its transactions and their messages are delivered at once to each plugin, so that each height is
+// either fully acknowledged or marked as failed and delivered again later on.
+func (m *Module) HandleBlock(
+	block *tmctypes.ResultBlock, results *tmctypes.ResultBlockResults, txs []*types.Transaction, vals *tmctypes.ResultValidators,
+) error {
+	if len(m.clients) == 0 {
+		return nil
+	}
+
+	reqs, err := buildRequests(block, results, txs, vals)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, client := range m.clients {
+		err = m.deliver(client, reqs)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// heightRequests contains all the requests that should be sent to the plugins for a single height
+type heightRequests struct {
+	height int64
+	block  *BlockRequest
+	txs    []*TxRequest
+	msgs   []*MessageRequest
+}
+
+// buildRequests builds the requests containing the given block, its transactions and their messages
+func buildRequests(
+	block *tmctypes.ResultBlock, results *tmctypes.ResultBlockResults, txs []*types.Transaction, vals *tmctypes.ResultValidators,
+) (*heightRequests, error) {
+	blockBz, err := tmjson.Marshal(block)
+	if err != nil {
+		return nil, fmt.Errorf("error while serializing block: %s", err)
+	}
+
+	resultsBz, err := tmjson.Marshal(results)
+	if err != nil {
+		return nil, fmt.Errorf("error while serializing block results: %s", err)
+	}
+
+	valsBz, err := tmjson.Marshal(vals)
+	if err != nil {
+		return nil, fmt.Errorf("error while serializing validators: %s", err)
+	}
+
+	height := block.Block.Height
+	reqs := &heightRequests{
+		height: height,
+		block: &BlockRequest{
+			Height:     height,
+			Block:      blockBz,
+			Results:    resultsBz,
+			Validators: valsBz,
+		},
+	}
+
+	for _, tx := range txs {
+		txBz, err := json.Marshal(tx)
+		if err != nil {
+			return nil, fmt.Errorf("error while serializing transaction %s: %s", tx.TxHash, err)
+		}
+
+		reqs.txs = append(reqs.txs, &TxRequest{
+			Height: height,
+			Tx:     txBz,
+		})
+
+		if tx.Tx == nil || tx.Body == nil {
+			continue
+		}
+
+		for index, msg := range tx.Body.Messages {
+			reqs.msgs = append(reqs.msgs, &MessageRequest{
+				Height: height,
+				TxHash: tx.TxHash,
+				Index:  index,
+				Type:   msg.GetType(),
+				Value:  msg.GetBytes(),
+			})
+		}
+	}
+
+	return reqs, nil
+}
+
+// deliver sends the given requests to the given plugin, stopping at the first one that is not acknowledged.
+// The height is then marked as either delivered or failed, so that it can be delivered again later on.
+func (m *Module) deliver(client *client, reqs *heightRequests) error {
+	deliveryErr := client.deliver(reqs)
+
+	err := client.tracker.Done(reqs.height, deliveryErr != nil)
+	if err != nil {
+		return fmt.Errorf("error while storing plugin %s progress: %s", client.cfg.Name, err)
+	}
+
+	if deliveryErr != nil {
+		return fmt.Errorf("error while delivering height %d to plugin %s: %s", reqs.height, client.cfg.Name, deliveryErr)
+	}
+
+	return nil
+}
+
+// deliver sends the given requests to the plugin, skipping the messages it does not handle
+func (c *client) deliver(reqs *heightRequests) error {
+	err := c.call(methodHandleBlock, reqs.block)
+	if err != nil {
+		return err
+	}
+
+	for _, req := range reqs.txs {
+		err = c.call(methodHandleTx, req)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, req := range reqs.msgs {
+		if !c.handlesMessage(req.Type) {
+			continue
+		}
+
+		err = c.call(methodHandleMsg, req)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// redeliverFailedHeights tries to deliver again all the heights that have not been acknowledged
+func (m *Module) redeliverFailedHeights() {
+	for _, client := range m.clients {
+		for _, height := range client.tracker.FailedHeights() {
+			err := m.redeliver(client, height)
+			if err != nil {
+				m.logger.Error("error while redelivering height", "err", err, "plugin", client.cfg.Name, "height", height)
+				break
+			}
+		}
+	}
+}
+
+// redeliver gets the data of the given height from the node, and delivers it again to the given plugin
+func (m *Module) redeliver(client *client, height int64) error {
+	block, err := m.node.Block(height)
+	if err != nil {
+		return fmt.Errorf("error while getting block: %s", err)
+	}
+
+	results, err := m.node.BlockResults(height)
+	if err != nil {
+		return fmt.Errorf("error while getting block results: %s", err)
+	}
+
+	txs, err := m.node.Txs(block)
+	if err != nil {
+		return fmt.Errorf("error while getting transactions: %s", err)
+	}
+
+	vals, err := m.node.Validators(height)
+	if err != nil {
+		return fmt.Errorf("error while getting validators: %s", err)
+	}
+
+	reqs, err := buildRequests(block, results, txs, vals)
+	if err != nil {
+		return err
+	}
+
+	return m.deliver(client, reqs)
+}
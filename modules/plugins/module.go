@@ -0,0 +1,178 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron"
+
+	"github.com/forbole/juno/v5/database"
+	"github.com/forbole/juno/v5/logging"
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/modules/progress"
+	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+const (
+	ModuleName = "plugins"
+
+	// DefaultTimeout represents the default max time that a plugin can take to acknowledge a single callback
+	DefaultTimeout = 30 * time.Second
+)
+
+var (
+	_ modules.Module                   = &Module{}
+	_ modules.InitializableModule      = &Module{}
+	_ modules.StartableModule          = &Module{}
+	_ modules.StoppableModule          = &Module{}
+	_ modules.HealthCheckModule        = &Module{}
+	_ modules.GenesisModule            = &Module{}
+	_ modules.BlockModule              = &Module{}
+	_ modules.PeriodicOperationsModule = &Module{}
+)
+
+// Module represents the module that forwards all the parsed data to external plugin processes over gRPC
+type Module struct {
+	junoCfg config.Config
+	cfg     *Config
+
+	node   node.Node
+	db     database.Database
+	store  *database.ModuleStore
+	logger logging.Logger
+
+	clients []*client
+}
+
+// NewModule returns a new Module instance.
+// The module configuration is parsed and validated when calling Init.
+func NewModule(cfg config.Config, node node.Node, db database.Database, logger logging.Logger) *Module {
+	return &Module{
+		junoCfg: cfg,
+		node:    node,
+		db:      db,
+		store:   database.NewModuleStore(db).Namespace(ModuleName),
+		logger:  logger,
+	}
+}
+
+// Name implements modules.Module
+func (m *Module) Name() string {
+	return ModuleName
+}
+
+// Init implements modules.InitializableModule
+func (m *Module) Init() error {
+	cfg, err := m.junoCfg.GetModuleConfig(ModuleName)
+	if err != nil {
+		return err
+	}
+
+	m.cfg = cfg.(*Config)
+
+	m.clients = nil
+	for _, pluginCfg := range m.cfg.Plugins {
+		m.clients = append(m.clients, newClient(pluginCfg, progress.NewTracker(m.store, pluginCfg.Name)))
+	}
+
+	return nil
+}
+
+// Start implements modules.StartableModule.
+// The progress of each plugin is resumed, so that all the heights that might have been stored without being
+// delivered before the last shutdown are delivered again.
+func (m *Module) Start() error {
+	if len(m.clients) == 0 {
+		return nil
+	}
+
+	lastHeight, err := m.db.GetLastBlockHeight()
+	if err != nil {
+		return fmt.Errorf("error while getting last block height: %s", err)
+	}
+
+	for _, client := range m.clients {
+		err = client.tracker.Resume(lastHeight)
+		if err != nil {
+			return fmt.Errorf("error while resuming plugin %s progress: %s", client.cfg.Name, err)
+		}
+
+		err = client.start()
+		if err != nil {
+			return fmt.Errorf("error while starting plugin %s: %s", client.cfg.Name, err)
+		}
+		m.logger.Info("plugin started", "plugin", client.cfg.Name, "address", client.cfg.Address)
+	}
+	return nil
+}
+
+// Stop implements modules.StoppableModule
+func (m *Module) Stop() error {
+	var errs []error
+	for _, client := range m.clients {
+		err := client.stop()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error while stopping plugin %s: %s", client.cfg.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HealthCheck implements modules.HealthCheckModule
+func (m *Module) HealthCheck() error {
+	var errs []error
+	for _, client := range m.clients {
+		err := client.healthCheck()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("plugin %s is not reachable: %s", client.cfg.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	if len(m.clients) == 0 {
+		return nil
+	}
+
+	_, err := scheduler.Every(m.cfg.RedeliveryInterval).SingletonMode().Do(func() {
+		m.redeliverFailedHeights()
+	})
+	if err != nil {
+		return fmt.Errorf("error while scheduling plugins redelivery: %s", err)
+	}
+
+	return nil
+}
+
+// GetCursor returns the height up to which all the heights have either been acknowledged by the plugin
+// having the given name or marked as failed
+func (m *Module) GetCursor(pluginName string) (int64, error) {
+	client, err := m.getClient(pluginName)
+	if err != nil {
+		return 0, err
+	}
+	return client.tracker.Cursor(), nil
+}
+
+// GetFailedHeights returns the heights that have not been acknowledged by the plugin having the given name yet
+func (m *Module) GetFailedHeights(pluginName string) ([]int64, error) {
+	client, err := m.getClient(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	return client.tracker.FailedHeights(), nil
+}
+
+// getClient returns the client of the plugin having the given name
+func (m *Module) getClient(pluginName string) (*client, error) {
+	for _, client := range m.clients {
+		if client.cfg.Name == pluginName {
+			return client, nil
+		}
+	}
+	return nil, fmt.Errorf("plugin %s not found", pluginName)
+}
@@ -0,0 +1,135 @@
+package plugins_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/forbole/juno/v5/database/testutils"
+	"github.com/forbole/juno/v5/logging"
+	"github.com/forbole/juno/v5/modules/plugins"
+	"github.com/forbole/juno/v5/types"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+// testPlugin represents a plugins.Handler that records the received callbacks
+type testPlugin struct {
+	blocks   []int64
+	messages []string
+}
+
+func (p *testPlugin) Info(_ context.Context, _ *plugins.InfoRequest) (*plugins.InfoResponse, error) {
+	return &plugins.InfoResponse{MessageTypes: []string{"/cosmos.bank.v1beta1.MsgSend"}}, nil
+}
+
+func (p *testPlugin) HandleGenesis(_ context.Context, _ *plugins.GenesisRequest) (*plugins.Ack, error) {
+	return &plugins.Ack{}, nil
+}
+
+func (p *testPlugin) HandleBlock(_ context.Context, req *plugins.BlockRequest) (*plugins.Ack, error) {
+	if req.Height == 13 {
+		return &plugins.Ack{Error: "unlucky height"}, nil
+	}
+	p.blocks = append(p.blocks, req.Height)
+	return &plugins.Ack{}, nil
+}
+
+func (p *testPlugin) HandleTx(_ context.Context, _ *plugins.TxRequest) (*plugins.Ack, error) {
+	return &plugins.Ack{}, nil
+}
+
+func (p *testPlugin) HandleMsg(_ context.Context, req *plugins.MessageRequest) (*plugins.Ack, error) {
+	p.messages = append(p.messages, req.Type)
+	return &plugins.Ack{}, nil
+}
+
+// testDb represents a database that contains the given last block height
+type testDb struct {
+	*testutils.ModuleStoreDb
+	lastHeight int64
+}
+
+func (db *testDb) GetLastBlockHeight() (int64, error) {
+	return db.lastHeight, nil
+}
+
+func TestModule(t *testing.T) {
+	// Start the plugin server
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	plugin := &testPlugin{}
+	server := grpc.NewServer()
+	plugins.RegisterHandler(server, plugin)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	// Build the module
+	junoCfg, err := config.DefaultConfigParser([]byte(fmt.Sprintf(`
+chain:
+  modules: [ plugins ]
+plugins:
+  plugins:
+    - name: test
+      address: %s
+`, listener.Addr().String())))
+	require.NoError(t, err)
+
+	db := &testDb{ModuleStoreDb: testutils.NewModuleStoreDb(), lastHeight: 11}
+	module := plugins.NewModule(junoCfg, nil, db, logging.DefaultLogger())
+	require.NoError(t, module.Init())
+	require.NoError(t, module.Start())
+	require.NoError(t, module.HealthCheck())
+
+	// Handle some blocks, each one containing a transaction with some messages
+	for _, height := range []int64{12, 13} {
+		block := &tmctypes.ResultBlock{Block: &tmtypes.Block{Header: tmtypes.Header{Height: height}}}
+		tx := &types.Transaction{
+			TxResponse: &types.TxResponse{TxResponse: &sdk.TxResponse{TxHash: "hash"}, Height: uint64(height)},
+			Tx: &types.Tx{Body: &types.TxBody{Messages: []types.Message{
+				types.NewStandardMessage(0, "/cosmos.bank.v1beta1.MsgSend", []byte(`{}`)),
+				types.NewStandardMessage(1, "/cosmos.gov.v1.MsgVote", []byte(`{}`)),
+			}}},
+		}
+
+		err = module.HandleBlock(block, &tmctypes.ResultBlockResults{}, []*types.Transaction{tx}, &tmctypes.ResultValidators{})
+		if height == 13 {
+			require.ErrorContains(t, err, "unlucky height")
+		} else {
+			require.NoError(t, err)
+		}
+	}
+	require.Equal(t, []int64{12}, plugin.blocks)
+
+	// Only the messages handled by the plugin should be sent, and only for the acknowledged blocks
+	require.Equal(t, []string{"/cosmos.bank.v1beta1.MsgSend"}, plugin.messages)
+
+	// Heights that have not been acknowledged should be tracked
+	cursor, err := module.GetCursor("test")
+	require.NoError(t, err)
+	require.Equal(t, int64(13), cursor)
+
+	failedHeights, err := module.GetFailedHeights("test")
+	require.NoError(t, err)
+	require.Equal(t, []int64{13}, failedHeights)
+
+	// After a restart, the heights stored by Juno after the cursor should be delivered again
+	require.NoError(t, module.Stop())
+	db.lastHeight = 15
+
+	module = plugins.NewModule(junoCfg, nil, db, logging.DefaultLogger())
+	require.NoError(t, module.Init())
+	require.NoError(t, module.Start())
+	defer module.Stop()
+
+	failedHeights, err = module.GetFailedHeights("test")
+	require.NoError(t, err)
+	require.Equal(t, []int64{13, 14, 15}, failedHeights)
+}
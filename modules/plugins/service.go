@@ -0,0 +1,108 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// ServiceName represents the name of the gRPC service that each plugin must expose
+	ServiceName = "juno.plugin.v1.Plugin"
+
+	// CodecName represents the name of the codec used to encode the messages exchanged with the plugins.
+	// Plugins receive requests having the "application/grpc+json" content type.
+	CodecName = "json"
+
+	methodInfo          = "/" + ServiceName + "/Info"
+	methodHandleGenesis = "/" + ServiceName + "/HandleGenesis"
+	methodHandleBlock   = "/" + ServiceName + "/HandleBlock"
+	methodHandleTx      = "/" + ServiceName + "/HandleTx"
+	methodHandleMsg     = "/" + ServiceName + "/HandleMsg"
+)
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec represents the gRPC codec encoding messages as JSON
+type jsonCodec struct{}
+
+// Marshal implements encoding.Codec
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements encoding.Codec
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// Name implements encoding.Codec
+func (jsonCodec) Name() string {
+	return CodecName
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// Handler represents the implementation of a plugin written in Go
+type Handler interface {
+	Info(ctx context.Context, req *InfoRequest) (*InfoResponse, error)
+	HandleGenesis(ctx context.Context, req *GenesisRequest) (*Ack, error)
+	HandleBlock(ctx context.Context, req *BlockRequest) (*Ack, error)
+	HandleTx(ctx context.Context, req *TxRequest) (*Ack, error)
+	HandleMsg(ctx context.Context, req *MessageRequest) (*Ack, error)
+}
+
+// RegisterHandler registers the given handler as the plugin service of the given gRPC server.
+// This can be used to easily write plugins in Go.
+func RegisterHandler(server *grpc.Server, handler Handler) {
+	server.RegisterService(&serviceDesc, handler)
+}
+
+// serviceDesc represents the description of the plugin gRPC service
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Handler)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Info", Handler: unaryHandler(methodInfo, func(h Handler, ctx context.Context, req *InfoRequest) (interface{}, error) {
+			return h.Info(ctx, req)
+		})},
+		{MethodName: "HandleGenesis", Handler: unaryHandler(methodHandleGenesis, func(h Handler, ctx context.Context, req *GenesisRequest) (interface{}, error) {
+			return h.HandleGenesis(ctx, req)
+		})},
+		{MethodName: "HandleBlock", Handler: unaryHandler(methodHandleBlock, func(h Handler, ctx context.Context, req *BlockRequest) (interface{}, error) {
+			return h.HandleBlock(ctx, req)
+		})},
+		{MethodName: "HandleTx", Handler: unaryHandler(methodHandleTx, func(h Handler, ctx context.Context, req *TxRequest) (interface{}, error) {
+			return h.HandleTx(ctx, req)
+		})},
+		{MethodName: "HandleMsg", Handler: unaryHandler(methodHandleMsg, func(h Handler, ctx context.Context, req *MessageRequest) (interface{}, error) {
+			return h.HandleMsg(ctx, req)
+		})},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// unaryHandler builds the gRPC method handler calling the given Handler method
+func unaryHandler[T any](
+	fullMethod string, call func(h Handler, ctx context.Context, req *T) (interface{}, error),
+) func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		req := new(T)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+
+		if interceptor == nil {
+			return call(srv.(Handler), ctx, req)
+		}
+
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}
+		return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return call(srv.(Handler), ctx, req.(*T))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package plugins
+
+import (
+	"encoding/json"
+)
+
+// The following types represent the messages exchanged with the plugins.
+// They are encoded as JSON so that plugins can be written in any language supporting gRPC with custom codecs.
+
+// InfoRequest is sent to a plugin when connecting to it
+type InfoRequest struct{}
+
+// InfoResponse contains the details of a plugin
+type InfoResponse struct {
+	// MessageTypes contains the type URLs of the messages the plugin handles.
+	// If empty, all the messages are sent to the plugin.
+	MessageTypes []string `json:"message_types,omitempty"`
+}
+
+// GenesisRequest contains the data of the genesis
+type GenesisRequest struct {
+	Genesis  json.RawMessage            `json:"genesis"`
+	AppState map[string]json.RawMessage `json:"app_state"`
+}
+
+// BlockRequest contains the data of a single block
+type BlockRequest struct {
+	Height     int64           `json:"height"`
+	Block      json.RawMessage `json:"block"`
+	Results    json.RawMessage `json:"results"`
+	Validators json.RawMessage `json:"validators"`
+}
+
+// TxRequest contains the data of a single transaction
+type TxRequest struct {
+	Height int64           `json:"height"`
+	Tx     json.RawMessage `json:"tx"`
+}
+
+// MessageRequest contains the data of a single message
+type MessageRequest struct {
+	Height int64           `json:"height"`
+	TxHash string          `json:"tx_hash"`
+	Index  int             `json:"index"`
+	Type   string          `json:"type"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// Ack represents the acknowledgement returned by a plugin after handling a callback.
+// If the plugin failed to handle the callback, Error contains the reason.
+type Ack struct {
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,200 @@
+package progress
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/forbole/juno/v5/database"
+)
+
+const (
+	// stateHeight represents the height at which the tracker values are stored inside the module store.
+	// The values are always stored at the same height, so that the latest value is the one that is read back
+	// regardless of the order in which the heights are handled.
+	stateHeight = 0
+
+	// maxPendingHeights represents the max number of heights after the cursor that can be handled before the cursor
+	// itself is handled. Once exceeded, the height following the cursor is marked as failed, so that the cursor can
+	// move on while the height is still delivered later on.
+	maxPendingHeights = 1000
+)
+
+// Tracker keeps track of the heights that have been delivered to a consumer (eg. a plugin or a sink), so that each
+// height is delivered at least once, even across restarts. To do so, it stores:
+//   - a cursor, such that every height up to it has either been delivered or marked as failed;
+//   - the list of the failed heights, that should be delivered again.
+//
+// It is safe for concurrent use.
+type Tracker struct {
+	store *database.ModuleStore
+	name  string
+
+	mu          sync.Mutex
+	initialized bool
+	cursor      int64
+	pending     map[int64]bool
+	failed      []int64
+}
+
+// NewTracker returns a new Tracker that stores the progress of the consumer having the given name
+// inside the given store
+func NewTracker(store *database.ModuleStore, name string) *Tracker {
+	return &Tracker{
+		store:   store,
+		name:    name,
+		pending: map[int64]bool{},
+	}
+}
+
+// cursorKey returns the key used to store the cursor
+func (t *Tracker) cursorKey() string {
+	return fmt.Sprintf("%s/cursor", t.name)
+}
+
+// failedHeightsKey returns the key used to store the failed heights
+func (t *Tracker) failedHeightsKey() string {
+	return fmt.Sprintf("%s/failed_heights", t.name)
+}
+
+// Resume loads the stored progress. All the heights after the stored cursor up to lastHeight, that is the last height
+// stored by Juno, are marked as failed so that they are delivered again, since they might have been stored without
+// being delivered before the last shutdown. The cursor is then moved to lastHeight.
+// If no cursor has been stored yet, the heights up to lastHeight are considered as delivered. If Juno has not stored
+// any height either, the cursor is set right before the first handled height.
+func (t *Tracker) Resume(lastHeight int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	found, err := t.store.Get(t.cursorKey(), &t.cursor)
+	if err != nil {
+		return fmt.Errorf("error while reading %s cursor: %s", t.name, err)
+	}
+
+	_, err = t.store.Get(t.failedHeightsKey(), &t.failed)
+	if err != nil {
+		return fmt.Errorf("error while reading %s failed heights: %s", t.name, err)
+	}
+
+	if !found {
+		if lastHeight == 0 {
+			return nil
+		}
+
+		t.initialized = true
+		t.cursor = lastHeight
+		return t.store.Set(t.cursorKey(), stateHeight, t.cursor)
+	}
+
+	t.initialized = true
+
+	if lastHeight <= t.cursor {
+		return nil
+	}
+
+	for height := t.cursor + 1; height <= lastHeight; height++ {
+		t.addFailed(height)
+	}
+	t.cursor = lastHeight
+
+	err = t.store.Set(t.failedHeightsKey(), stateHeight, t.failed)
+	if err != nil {
+		return fmt.Errorf("error while storing %s failed heights: %s", t.name, err)
+	}
+
+	return t.store.Set(t.cursorKey(), stateHeight, t.cursor)
+}
+
+// Cursor returns the height up to which all the heights have either been delivered or marked as failed
+func (t *Tracker) Cursor() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cursor
+}
+
+// FailedHeights returns the heights that have not been delivered yet, sorted in ascending order
+func (t *Tracker) FailedHeights() []int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]int64(nil), t.failed...)
+}
+
+// Done records that the given height has been handled, either successfully or not.
+// Failed heights are added to the failed heights, while delivered heights are removed from them.
+func (t *Tracker) Done(height int64, failed bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	oldCursor := t.cursor
+	if !t.initialized {
+		t.initialized = true
+		t.cursor = height - 1
+	}
+
+	var failedChanged bool
+	if failed {
+		failedChanged = t.addFailed(height)
+	} else {
+		failedChanged = t.removeFailed(height)
+	}
+
+	if height > t.cursor {
+		t.pending[height] = true
+	}
+	if t.advanceCursor() {
+		failedChanged = true
+	}
+
+	if failedChanged {
+		err := t.store.Set(t.failedHeightsKey(), stateHeight, t.failed)
+		if err != nil {
+			return fmt.Errorf("error while storing %s failed heights: %s", t.name, err)
+		}
+	}
+
+	if t.cursor != oldCursor {
+		err := t.store.Set(t.cursorKey(), stateHeight, t.cursor)
+		if err != nil {
+			return fmt.Errorf("error while storing %s cursor: %s", t.name, err)
+		}
+	}
+
+	return nil
+}
+
+// advanceCursor moves the cursor forward as long as the following heights have been handled.
+// If too many heights are pending, the heights following the cursor are marked as failed, in which case true is returned.
+func (t *Tracker) advanceCursor() (failedChanged bool) {
+	for t.pending[t.cursor+1] || len(t.pending) > maxPendingHeights {
+		if !t.pending[t.cursor+1] && t.addFailed(t.cursor+1) {
+			failedChanged = true
+		}
+		delete(t.pending, t.cursor+1)
+		t.cursor++
+	}
+	return failedChanged
+}
+
+// addFailed adds the given height to the failed ones, returning true if it was not present
+func (t *Tracker) addFailed(height int64) bool {
+	index := sort.Search(len(t.failed), func(i int) bool { return t.failed[i] >= height })
+	if index < len(t.failed) && t.failed[index] == height {
+		return false
+	}
+
+	t.failed = append(t.failed, 0)
+	copy(t.failed[index+1:], t.failed[index:])
+	t.failed[index] = height
+	return true
+}
+
+// removeFailed removes the given height from the failed ones, returning true if it was present
+func (t *Tracker) removeFailed(height int64) bool {
+	index := sort.Search(len(t.failed), func(i int) bool { return t.failed[i] >= height })
+	if index == len(t.failed) || t.failed[index] != height {
+		return false
+	}
+
+	t.failed = append(t.failed[:index], t.failed[index+1:]...)
+	return true
+}
@@ -0,0 +1,56 @@
+package progress_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/database"
+	"github.com/forbole/juno/v5/database/testutils"
+	"github.com/forbole/juno/v5/modules/progress"
+)
+
+func TestTracker(t *testing.T) {
+	store := database.NewModuleStore(testutils.NewModuleStoreDb()).Namespace("test")
+
+	// Without a stored cursor, all the heights up to the last one should be considered as delivered
+	tracker := progress.NewTracker(store, "consumer")
+	require.NoError(t, tracker.Resume(100))
+	require.Equal(t, int64(100), tracker.Cursor())
+	require.Empty(t, tracker.FailedHeights())
+
+	// Heights handled out of order should only move the cursor once all the previous ones have been handled
+	require.NoError(t, tracker.Done(103, true))
+	require.NoError(t, tracker.Done(102, false))
+	require.Equal(t, int64(100), tracker.Cursor())
+
+	require.NoError(t, tracker.Done(101, true))
+	require.Equal(t, int64(103), tracker.Cursor())
+	require.Equal(t, []int64{101, 103}, tracker.FailedHeights())
+
+	// Delivered heights should be removed from the failed ones
+	require.NoError(t, tracker.Done(103, false))
+	require.Equal(t, []int64{101}, tracker.FailedHeights())
+
+	// Handle a height that is not delivered before shutting down
+	require.NoError(t, tracker.Done(105, false))
+	require.Equal(t, int64(103), tracker.Cursor())
+
+	// After a restart, the heights after the cursor should be marked as failed
+	tracker = progress.NewTracker(store, "consumer")
+	require.NoError(t, tracker.Resume(105))
+	require.Equal(t, int64(105), tracker.Cursor())
+	require.Equal(t, []int64{101, 104, 105}, tracker.FailedHeights())
+
+	// Other consumers should have their own progress
+	other := progress.NewTracker(store, "other")
+	require.NoError(t, other.Resume(105))
+	require.Empty(t, other.FailedHeights())
+
+	// Without any stored height, the cursor should start right before the first handled height
+	empty := progress.NewTracker(store, "empty")
+	require.NoError(t, empty.Resume(0))
+	require.NoError(t, empty.Done(1000, false))
+	require.Equal(t, int64(1000), empty.Cursor())
+	require.Empty(t, empty.FailedHeights())
+}
@@ -1,13 +1,46 @@
 package pruning
 
 import (
+	"fmt"
+	"time"
+
 	"gopkg.in/yaml.v3"
+
+	"github.com/forbole/juno/v5/types/config"
+)
+
+var (
+	_ config.ModuleConfig = &Config{}
 )
 
+func init() {
+	config.RegisterRequiredModuleConfig(ModuleName, "pruning", func() config.ModuleConfig {
+		return DefaultConfig()
+	})
+}
+
+// Config represents the configuration of the pruning module
 type Config struct {
 	KeepRecent int64 `yaml:"keep_recent"`
 	KeepEvery  int64 `yaml:"keep_every"`
 	Interval   int64 `yaml:"interval"`
+
+	// Frequency represents how often the background job checks whether the database should be pruned
+	Frequency time.Duration `yaml:"frequency"`
+
+	// BatchSize represents the max number of heights that are deleted from a table with a single statement
+	BatchSize int64 `yaml:"batch_size"`
+
+	// Throttle represents the time to wait between the deletion of one batch and the next one
+	Throttle time.Duration `yaml:"throttle,omitempty"`
+
+	// Tables contains the retention policies of the database tables, indexed by the table name.
+	// The pre_commit, message and address_message tables use the keep_recent and keep_every values by default.
+	Tables map[string]RetentionPolicy `yaml:"tables,omitempty"`
+
+	// Modules contains the retention policies of the modules implementing modules.PrunableModule,
+	// indexed by the module name. Modules without a retention policy are never pruned.
+	Modules map[string]RetentionPolicy `yaml:"modules,omitempty"`
 }
 
 // NewConfig allows to build a new Config instance
@@ -16,9 +49,80 @@ func NewConfig(keepRecent, keepEvery, interval int64) *Config {
 		KeepRecent: keepRecent,
 		KeepEvery:  keepEvery,
 		Interval:   interval,
+		Frequency:  time.Minute,
+		BatchSize:  1000,
 	}
 }
 
+// DefaultConfig returns the default Config instance
+func DefaultConfig() *Config {
+	return NewConfig(100, 500, 10)
+}
+
+// Validate implements config.ModuleConfig
+func (c *Config) Validate() error {
+	if c.KeepRecent <= 0 {
+		return fmt.Errorf("keep_recent must be greater than 0")
+	}
+
+	if c.KeepEvery < 0 {
+		return fmt.Errorf("keep_every cannot be negative")
+	}
+
+	if c.Interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+
+	if c.Frequency <= 0 {
+		return fmt.Errorf("frequency must be greater than 0")
+	}
+
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be greater than 0")
+	}
+
+	if c.Throttle < 0 {
+		return fmt.Errorf("throttle cannot be negative")
+	}
+
+	for table, policy := range c.Tables {
+		if !isPrunableTable(table) {
+			return fmt.Errorf("table %s cannot be pruned", table)
+		}
+
+		err := policy.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid retention policy of table %s: %s", table, err)
+		}
+	}
+
+	for module, policy := range c.Modules {
+		err := policy.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid retention policy of module %s: %s", module, err)
+		}
+	}
+
+	return nil
+}
+
+// GetTablesPolicies returns the retention policies of all the tables that should be pruned, indexed by table name
+func (c *Config) GetTablesPolicies() map[string]RetentionPolicy {
+	defaultPolicy := NewRetentionPolicy(c.KeepRecent, 0, c.KeepEvery)
+	policies := map[string]RetentionPolicy{
+		TablePreCommit:      defaultPolicy,
+		TableMessage:        defaultPolicy,
+		TableAddressMessage: defaultPolicy,
+	}
+
+	for table, policy := range c.Tables {
+		policies[table] = policy
+	}
+	return policies
+}
+
+// ParseConfig allows to parse the pruning section of the given config bytes.
+// If the section is not present, nil is returned.
 func ParseConfig(bz []byte) (*Config, error) {
 	type T struct {
 		Config *Config `yaml:"pruning"`
@@ -27,3 +131,49 @@ func ParseConfig(bz []byte) (*Config, error) {
 	err := yaml.Unmarshal(bz, &cfg)
 	return cfg.Config, err
 }
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// RetentionPolicy tells for how long the data of each height should be kept.
+// If both KeepRecent and KeepFor are set, the data is kept as long as any of them requires it.
+type RetentionPolicy struct {
+	// KeepRecent represents the number of recent heights whose data should be kept
+	KeepRecent int64 `yaml:"keep_recent,omitempty"`
+
+	// KeepFor represents the time for which the data of each height should be kept, based on the block timestamp
+	KeepFor time.Duration `yaml:"keep_for,omitempty"`
+
+	// KeepEvery allows to keep the data of every nth height, even if it should have been pruned.
+	// Note that whole partitions are never dropped when this is set.
+	KeepEvery int64 `yaml:"keep_every,omitempty"`
+}
+
+// NewRetentionPolicy allows to build a new RetentionPolicy instance
+func NewRetentionPolicy(keepRecent int64, keepFor time.Duration, keepEvery int64) RetentionPolicy {
+	return RetentionPolicy{
+		KeepRecent: keepRecent,
+		KeepFor:    keepFor,
+		KeepEvery:  keepEvery,
+	}
+}
+
+// Validate returns an error if the policy is not valid
+func (p RetentionPolicy) Validate() error {
+	if p.KeepRecent < 0 {
+		return fmt.Errorf("keep_recent cannot be negative")
+	}
+
+	if p.KeepFor < 0 {
+		return fmt.Errorf("keep_for cannot be negative")
+	}
+
+	if p.KeepRecent == 0 && p.KeepFor == 0 {
+		return fmt.Errorf("either keep_recent or keep_for must be set")
+	}
+
+	if p.KeepEvery < 0 {
+		return fmt.Errorf("keep_every cannot be negative")
+	}
+
+	return nil
+}
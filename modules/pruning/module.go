@@ -1,6 +1,11 @@
 package pruning
 
 import (
+	"fmt"
+	"sync"
+
+	"github.com/go-co-op/gocron"
+
 	"github.com/forbole/juno/v5/types/config"
 
 	"github.com/forbole/juno/v5/logging"
@@ -9,44 +14,106 @@ import (
 	"github.com/forbole/juno/v5/modules"
 )
 
+const (
+	ModuleName = "pruning"
+)
+
 var (
-	_ modules.Module                     = &Module{}
-	_ modules.BlockModule                = &Module{}
-	_ modules.AdditionalOperationsModule = &Module{}
+	_ modules.Module                   = &Module{}
+	_ modules.InitializableModule      = &Module{}
+	_ modules.ModulesAwareModule       = &Module{}
+	_ modules.PeriodicOperationsModule = &Module{}
+	_ modules.StoppableModule          = &Module{}
+	_ modules.BlocksPruningModule      = &Module{}
 )
 
 // Module represents the pruning module allowing to clean the database periodically
 type Module struct {
-	cfg    *Config
-	db     database.Database
-	logger logging.Logger
+	junoCfg config.Config
+	cfg     *Config
+	db      database.Database
+	store   *database.ModuleStore
+	mods    []modules.Module
+	logger  logging.Logger
+
+	// lastRunHeight contains the latest height considered by the last pruning run
+	lastRunHeight int64
+
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
-// NewModule builds a new Module instance
+// NewModule builds a new Module instance.
+// The module configuration is parsed and validated when calling Init.
 func NewModule(cfg config.Config, db database.Database, logger logging.Logger) *Module {
-	bz, err := cfg.GetBytes()
-	if err != nil {
-		panic(err)
+	return &Module{
+		junoCfg: cfg,
+		db:      db,
+		store:   database.NewModuleStore(db).Namespace(ModuleName),
+		logger:  logger,
+		stop:    make(chan struct{}),
 	}
+}
+
+// Name implements modules.Module
+func (m *Module) Name() string {
+	return ModuleName
+}
 
-	pruningCfg, err := ParseConfig(bz)
+// Init implements modules.InitializableModule
+func (m *Module) Init() error {
+	cfg, err := m.junoCfg.GetModuleConfig(ModuleName)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	return &Module{
-		cfg:    pruningCfg,
-		db:     db,
-		logger: logger,
+	m.cfg = cfg.(*Config)
+
+	// Make sure the modules having a retention policy can be pruned
+	for name := range m.cfg.Modules {
+		module, found := modules.Modules(m.mods).FindByName(name)
+		if !found {
+			continue
+		}
+
+		if _, ok := module.(modules.PrunableModule); !ok {
+			return fmt.Errorf("module %s has a retention policy but does not implement PrunableModule", name)
+		}
 	}
+
+	return nil
 }
 
-// Name implements modules.Module
-func (m *Module) Name() string {
-	return "pruning"
+// SetModules implements modules.ModulesAwareModule
+func (m *Module) SetModules(mods []modules.Module) {
+	m.mods = mods
+}
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	_, err := scheduler.Every(m.cfg.Frequency).SingletonMode().Do(func() {
+		err := m.runPruning()
+		if err != nil {
+			m.logger.Error("error while pruning", "module", ModuleName, "err", err)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("error while scheduling pruning: %s", err)
+	}
+
+	return nil
+}
+
+// GetBlocksPrunedHeight implements modules.BlocksPruningModule
+func (m *Module) GetBlocksPrunedHeight() (int64, error) {
+	return m.getProgress(getTableProgressKey(TableBlock))
 }
 
-// RunAdditionalOperations implements
-func (m *Module) RunAdditionalOperations() error {
-	return RunAdditionalOperations(m.cfg)
+// Stop implements modules.StoppableModule
+func (m *Module) Stop() error {
+	// Interrupt the running pruning, if any, as soon as the current batch is deleted
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+	return nil
 }
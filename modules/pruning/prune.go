@@ -0,0 +1,324 @@
+package pruning
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/forbole/juno/v5/database"
+	"github.com/forbole/juno/v5/modules"
+)
+
+// errStopped is returned when the pruning is interrupted because the module is being stopped
+var errStopped = errors.New("pruning stopped")
+
+// runPruning prunes the database based on the last stored block, if at least Interval heights have been stored
+// since the last run
+func (m *Module) runPruning() error {
+	pruningDb, ok := m.db.(database.RetentionPruningDb)
+	if !ok {
+		return fmt.Errorf("pruning is enabled, but your database does not implement RetentionPruningDb")
+	}
+
+	latestHeight, latestTime, err := pruningDb.GetLastBlockHeightAndTime()
+	if err != nil {
+		return fmt.Errorf("error while getting last block: %s", err)
+	}
+
+	if latestHeight == 0 || (m.lastRunHeight > 0 && latestHeight-m.lastRunHeight < m.cfg.Interval) {
+		// Nothing has been stored yet, or not enough heights have been stored since the last run
+		return nil
+	}
+
+	err = m.prune(pruningDb, latestHeight, latestTime)
+	if err == errStopped {
+		m.logger.Info("pruning interrupted, it will be resumed at the next run", "module", ModuleName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	m.lastRunHeight = latestHeight
+	return nil
+}
+
+// prune prunes all the tables and modules based on their retention policies, considering the
+// given height and time as the latest ones
+func (m *Module) prune(pruningDb database.RetentionPruningDb, latestHeight int64, latestTime time.Time) error {
+	// Get the height before which the whole database has already been pruned, in case the progress of a
+	// table has not been stored yet (eg. it has been pruned by a previous version)
+	lastPruned, err := pruningDb.GetLastPruned()
+	if err != nil {
+		return fmt.Errorf("error while getting last pruned height: %s", err)
+	}
+
+	// Compute the height before which each table should be pruned
+	policies := m.cfg.GetTablesPolicies()
+	pruneHeights := map[string]int64{}
+	for _, table := range prunableTables {
+		policy, found := policies[table]
+		if !found {
+			continue
+		}
+
+		pruneHeight, err := getPruneHeight(pruningDb, policy, latestHeight, latestTime)
+		if err != nil {
+			return err
+		}
+		pruneHeights[table] = pruneHeight
+	}
+
+	// Make sure the rows referencing other rows are deleted along with them
+	for i := len(prunableTables) - 1; i >= 0; i-- {
+		table := prunableTables[i]
+		referenced, found := referencedTables[table]
+		if found && pruneHeights[referenced] > pruneHeights[table] {
+			pruneHeights[table] = pruneHeights[referenced]
+		}
+	}
+
+	// Make sure the rows referenced by the kept rows are kept as well
+	keepEvery := getTablesKeepEvery(policies, pruneHeights)
+
+	// Prune the tables
+	newLastPruned := latestHeight
+	for _, table := range prunableTables {
+		pruneHeight, found := pruneHeights[table]
+		if !found {
+			continue
+		}
+
+		err = m.pruneTable(pruningDb, table, lastPruned, pruneHeight, keepEvery[table])
+		if err == errStopped {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("error while pruning table %s: %s", table, err)
+		}
+
+		if pruneHeight < newLastPruned {
+			newLastPruned = pruneHeight
+		}
+	}
+
+	// Prune the modules
+	for _, module := range m.mods {
+		prunable, ok := module.(modules.PrunableModule)
+		if !ok {
+			continue
+		}
+
+		policy, found := m.cfg.Modules[module.Name()]
+		if !found {
+			continue
+		}
+
+		pruneHeight, err := getPruneHeight(pruningDb, policy, latestHeight, latestTime)
+		if err != nil {
+			return err
+		}
+
+		err = m.pruneModule(prunable, module.Name(), pruneHeight)
+		if err != nil {
+			return fmt.Errorf("error while pruning module %s: %s", module.Name(), err)
+		}
+	}
+
+	// Prune the old modules states versions
+	if storeDb, ok := m.db.(database.ModuleStoreDb); ok {
+		err = storeDb.PruneModuleStates(newLastPruned)
+		if err != nil {
+			return fmt.Errorf("error while pruning modules states: %s", err)
+		}
+	}
+
+	return pruningDb.StoreLastPruned(newLastPruned)
+}
+
+// getTablesKeepEvery returns, for each one of the given tables that should be pruned, the values such that the rows
+// having a height that is a multiple of any of them should be kept. Each table inherits the values of the tables
+// referencing it, so that the rows referenced by the kept rows are never deleted.
+func getTablesKeepEvery(policies map[string]RetentionPolicy, pruneHeights map[string]int64) map[string][]int64 {
+	keepEvery := map[string][]int64{}
+	for _, table := range prunableTables {
+		if _, found := pruneHeights[table]; !found {
+			continue
+		}
+
+		if policy := policies[table]; policy.KeepEvery > 0 {
+			keepEvery[table] = appendKeepEvery(keepEvery[table], policy.KeepEvery)
+		}
+
+		// Tables are sorted so that each table comes before the one it references, so all the values
+		// inherited by this table have already been added to it
+		if referenced, found := referencedTables[table]; found {
+			keepEvery[referenced] = appendKeepEvery(keepEvery[referenced], keepEvery[table]...)
+		}
+	}
+	return keepEvery
+}
+
+// appendKeepEvery appends the given values to the given slice, skipping the ones that are already present
+func appendKeepEvery(slice []int64, values ...int64) []int64 {
+	for _, value := range values {
+		found := false
+		for _, v := range slice {
+			if v == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			slice = append(slice, value)
+		}
+	}
+	return slice
+}
+
+// getPruneHeight returns the height before which the data should be pruned based on the given policy
+func getPruneHeight(
+	db database.RetentionPruningDb, policy RetentionPolicy, latestHeight int64, latestTime time.Time,
+) (int64, error) {
+	pruneHeight := latestHeight
+	if policy.KeepRecent > 0 {
+		pruneHeight = latestHeight - policy.KeepRecent
+	}
+
+	if policy.KeepFor > 0 {
+		lastHeight, err := db.GetLastHeightBefore(latestTime.Add(-policy.KeepFor))
+		if err != nil {
+			return 0, fmt.Errorf("error while getting last height before %s: %s", latestTime.Add(-policy.KeepFor), err)
+		}
+
+		// Keep the data as long as any of the limits requires it
+		if lastHeight+1 < pruneHeight {
+			pruneHeight = lastHeight + 1
+		}
+	}
+
+	if pruneHeight < 0 {
+		return 0, nil
+	}
+	return pruneHeight, nil
+}
+
+// pruneTable deletes the rows of the given table having a height lower than the given one, starting from
+// the height reached by the previous runs, or from lastPruned if the table has never been pruned by this module.
+// Whole partitions are dropped when possible, while the remaining rows are deleted in batches.
+// The progress is stored after each batch so that the pruning can be resumed if interrupted.
+func (m *Module) pruneTable(
+	db database.RetentionPruningDb, table string, lastPruned int64, pruneHeight int64, keepEvery []int64,
+) error {
+	progressKey := getTableProgressKey(table)
+	fromHeight, err := m.getProgress(progressKey)
+	if err != nil {
+		return err
+	}
+
+	if fromHeight == 0 {
+		fromHeight = lastPruned
+	}
+
+	if fromHeight >= pruneHeight {
+		return nil
+	}
+
+	dropped, err := db.DropPrunedPartitions(table, pruneHeight, keepEvery)
+	if err != nil {
+		return err
+	}
+	if len(dropped) > 0 {
+		m.logger.Info("dropped pruned partitions", "module", ModuleName, "partitions", dropped)
+	}
+
+	// Skip the heights that are not stored
+	lowestHeight, err := db.GetLowestHeight(table)
+	if err != nil {
+		return err
+	}
+	if lowestHeight > fromHeight {
+		fromHeight = lowestHeight
+	}
+
+	for fromHeight < pruneHeight {
+		toHeight := fromHeight + m.cfg.BatchSize
+		if toHeight > pruneHeight {
+			toHeight = pruneHeight
+		}
+
+		m.logger.Debug("pruning", "module", ModuleName, "table", table, "from", fromHeight, "to", toHeight)
+		err = db.PruneTable(table, fromHeight, toHeight, keepEvery)
+		if err != nil {
+			return fmt.Errorf("error while pruning heights [%d, %d): %s", fromHeight, toHeight, err)
+		}
+
+		err = m.setProgress(progressKey, toHeight)
+		if err != nil {
+			return err
+		}
+		fromHeight = toHeight
+
+		err = m.throttle()
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.setProgress(progressKey, pruneHeight)
+}
+
+// throttle waits for the configured time between two batches, returning errStopped if the module is stopped
+func (m *Module) throttle() error {
+	select {
+	case <-m.stop:
+		return errStopped
+	case <-time.After(m.cfg.Throttle):
+		return nil
+	}
+}
+
+// pruneModule prunes the data of the given module having a height lower than the given one
+func (m *Module) pruneModule(module modules.PrunableModule, name string, pruneHeight int64) error {
+	progressKey := fmt.Sprintf("modules/%s", name)
+	fromHeight, err := m.getProgress(progressKey)
+	if err != nil {
+		return err
+	}
+
+	if fromHeight >= pruneHeight {
+		return nil
+	}
+
+	m.logger.Debug("pruning", "module", ModuleName, "pruned_module", name, "from", fromHeight, "to", pruneHeight)
+	err = module.Prune(fromHeight, pruneHeight)
+	if err != nil {
+		return err
+	}
+
+	return m.setProgress(progressKey, pruneHeight)
+}
+
+// getTableProgressKey returns the key used to store the pruning progress of the given table
+func getTableProgressKey(table string) string {
+	return fmt.Sprintf("tables/%s", table)
+}
+
+// getProgress returns the height before which the data having the given key has already been pruned
+func (m *Module) getProgress(key string) (int64, error) {
+	var height int64
+	_, err := m.store.Get(key, &height)
+	if err != nil {
+		return 0, fmt.Errorf("error while getting pruning progress: %s", err)
+	}
+	return height, nil
+}
+
+// setProgress stores the height before which the data having the given key has been pruned
+func (m *Module) setProgress(key string, height int64) error {
+	err := m.store.Set(key, height, height)
+	if err != nil {
+		return fmt.Errorf("error while storing pruning progress: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package pruning_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/database/testutils"
+	"github.com/forbole/juno/v5/logging"
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/modules/pruning"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+// mockPruningDb represents an in-memory database.RetentionPruningDb implementation recording the pruned heights.
+// A value is sent to the done channel each time a pruning run ends, either successfully or because of a failure.
+type mockPruningDb struct {
+	*testutils.ModuleStoreDb
+
+	latestHeight int64
+	failAt       string
+	done         chan struct{}
+
+	pruned     []string
+	dropped    []string
+	lastPruned int64
+}
+
+func newMockPruningDb(latestHeight int64) *mockPruningDb {
+	return &mockPruningDb{
+		latestHeight: latestHeight,
+		done:         make(chan struct{}, 1),
+
+		ModuleStoreDb: testutils.NewModuleStoreDb(),
+	}
+}
+
+func (db *mockPruningDb) Prune(_ int64) error {
+	return nil
+}
+
+func (db *mockPruningDb) StoreLastPruned(height int64) error {
+	db.lastPruned = height
+	db.done <- struct{}{}
+	return nil
+}
+
+func (db *mockPruningDb) GetLastPruned() (int64, error) {
+	return db.lastPruned, nil
+}
+
+func (db *mockPruningDb) PruneTable(table string, fromHeight, toHeight int64, keepEvery []int64) error {
+	if fmt.Sprintf("%s [%d, %d)", table, fromHeight, toHeight) == db.failAt {
+		db.failAt = ""
+		db.done <- struct{}{}
+		return fmt.Errorf("error while pruning")
+	}
+
+	db.pruned = append(db.pruned, fmt.Sprintf("%s [%d, %d) %v", table, fromHeight, toHeight, keepEvery))
+	return nil
+}
+
+func (db *mockPruningDb) DropPrunedPartitions(table string, height int64, keepEvery []int64) ([]string, error) {
+	db.dropped = append(db.dropped, fmt.Sprintf("%s < %d %v", table, height, keepEvery))
+	return nil, nil
+}
+
+func (db *mockPruningDb) GetLowestHeight(_ string) (int64, error) {
+	return 1, nil
+}
+
+func (db *mockPruningDb) GetLastHeightBefore(timestamp time.Time) (int64, error) {
+	// Each block is produced one second after the previous one
+	return timestamp.Unix() - 1, nil
+}
+
+func (db *mockPruningDb) GetLastBlockHeightAndTime() (int64, time.Time, error) {
+	// Each block is produced one second after the previous one
+	return db.latestHeight, time.Unix(db.latestHeight, 0), nil
+}
+
+// prunableModule represents a modules.PrunableModule recording the pruned heights
+type prunableModule struct {
+	pruned [][2]int64
+}
+
+func (m *prunableModule) Name() string {
+	return "prunable"
+}
+
+func (m *prunableModule) Prune(fromHeight, toHeight int64) error {
+	m.pruned = append(m.pruned, [2]int64{fromHeight, toHeight})
+	return nil
+}
+
+// startPruning initializes the pruning module with the given modules and runs its periodic operations once
+func startPruning(t *testing.T, module *pruning.Module, db *mockPruningDb, mods ...modules.Module) *gocron.Scheduler {
+	require.NoError(t, modules.InitModules(append([]modules.Module{module}, mods...)))
+
+	scheduler := gocron.NewScheduler(time.UTC)
+	require.NoError(t, module.RegisterPeriodicOperations(scheduler))
+	scheduler.StartAsync()
+	waitPruning(t, db)
+	return scheduler
+}
+
+// waitPruning waits for the current pruning run to end
+func waitPruning(t *testing.T, db *mockPruningDb) {
+	select {
+	case <-db.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pruning did not end in time")
+	}
+}
+
+func TestModule_RegisterPeriodicOperations(t *testing.T) {
+	junoCfg, err := config.DefaultConfigParser([]byte(`
+chain:
+  modules: [ pruning, prunable ]
+pruning:
+  keep_recent: 1500
+  keep_every: 0
+  interval: 10
+  frequency: 1h
+  batch_size: 1000
+  tables:
+    transaction:
+      keep_recent: 500
+    block:
+      keep_for: 10m
+  modules:
+    prunable:
+      keep_recent: 100
+`))
+	require.NoError(t, err)
+
+	db := newMockPruningDb(2000)
+	module := pruning.NewModule(junoCfg, db, logging.DefaultLogger())
+	prunable := &prunableModule{}
+	scheduler := startPruning(t, module, db, prunable)
+	defer scheduler.Stop()
+
+	// Referencing tables should be pruned at least as much as the tables they reference,
+	// while the other tables should be pruned in batches based on their own policies
+	require.Equal(t, []string{
+		"address_message [1, 500) []",
+		"message [1, 1001) []",
+		"message [1001, 1500) []",
+		"transaction [1, 1001) []",
+		"transaction [1001, 1500) []",
+		"pre_commit [1, 500) []",
+		"block [1, 1001) []",
+		"block [1001, 1400) []",
+	}, db.pruned)
+	require.Equal(t, [][2]int64{{0, 1900}}, prunable.pruned)
+	require.Equal(t, int64(500), db.lastPruned)
+
+	// The blocks pruned height should be exposed so that they are not parsed again
+	blocksPrunedHeight, err := module.GetBlocksPrunedHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(1400), blocksPrunedHeight)
+
+	// Pruning again should only prune the new heights
+	db.pruned = nil
+	db.latestHeight = 2010
+	scheduler.RunAll()
+	waitPruning(t, db)
+
+	require.Equal(t, []string{
+		"address_message [500, 510) []",
+		"message [1500, 1510) []",
+		"transaction [1500, 1510) []",
+		"pre_commit [500, 510) []",
+		"block [1400, 1410) []",
+	}, db.pruned)
+	require.Equal(t, [][2]int64{{0, 1900}, {1900, 1910}}, prunable.pruned)
+}
+
+func TestModule_RegisterPeriodicOperations_Resume(t *testing.T) {
+	junoCfg, err := config.DefaultConfigParser([]byte(`
+chain:
+  modules: [ pruning ]
+pruning:
+  keep_recent: 1500
+  keep_every: 0
+  interval: 10
+  frequency: 1h
+  batch_size: 500
+  tables:
+    transaction:
+      keep_recent: 500
+`))
+	require.NoError(t, err)
+
+	db := newMockPruningDb(2000)
+	db.failAt = "transaction [501, 1001)"
+	module := pruning.NewModule(junoCfg, db, logging.DefaultLogger())
+	scheduler := startPruning(t, module, db)
+	defer scheduler.Stop()
+
+	require.Equal(t, []string{
+		"address_message [1, 500) []",
+		"message [1, 501) []",
+		"message [501, 1001) []",
+		"message [1001, 1500) []",
+		"transaction [1, 501) []",
+	}, db.pruned)
+
+	// The next run should resume from the last pruned batch
+	db.pruned = nil
+	scheduler.RunAll()
+	waitPruning(t, db)
+
+	require.Equal(t, []string{
+		"transaction [501, 1001) []",
+		"transaction [1001, 1500) []",
+		"pre_commit [1, 500) []",
+	}, db.pruned)
+	require.Equal(t, int64(500), db.lastPruned)
+}
+
+func TestModule_RegisterPeriodicOperations_KeepEvery(t *testing.T) {
+	junoCfg, err := config.DefaultConfigParser([]byte(`
+chain:
+  modules: [ pruning ]
+pruning:
+  keep_recent: 1500
+  keep_every: 500
+  interval: 10
+  frequency: 1h
+  batch_size: 1000
+  tables:
+    transaction:
+      keep_recent: 500
+    block:
+      keep_recent: 500
+      keep_every: 300
+`))
+	require.NoError(t, err)
+
+	db := newMockPruningDb(2000)
+	module := pruning.NewModule(junoCfg, db, logging.DefaultLogger())
+	scheduler := startPruning(t, module, db)
+	defer scheduler.Stop()
+
+	// The transactions referenced by the kept messages should be kept, along with the blocks they reference
+	require.Equal(t, []string{
+		"address_message [1, 500) [500]",
+		"message [1, 1001) [500]",
+		"message [1001, 1500) [500]",
+		"transaction [1, 1001) [500]",
+		"transaction [1001, 1500) [500]",
+		"pre_commit [1, 500) [500]",
+		"block [1, 1001) [500 300]",
+		"block [1001, 1500) [500 300]",
+	}, db.pruned)
+}
+
+func TestModule_RegisterPeriodicOperations_DefaultConfig(t *testing.T) {
+	junoCfg, err := config.DefaultConfigParser([]byte(`
+chain:
+  modules: [ pruning ]
+pruning: {}
+`))
+	require.NoError(t, err)
+
+	db := newMockPruningDb(2000)
+	module := pruning.NewModule(junoCfg, db, logging.DefaultLogger())
+	scheduler := startPruning(t, module, db)
+	defer scheduler.Stop()
+
+	// The pruned partitions should be dropped even if some heights need to be kept,
+	// while the transactions and blocks should only be pruned when configured explicitly
+	require.Equal(t, []string{
+		"address_message < 1900 [500]",
+		"message < 1900 [500]",
+		"pre_commit < 1900 [500]",
+	}, db.dropped)
+	require.Equal(t, []string{
+		"address_message [1, 1001) [500]",
+		"address_message [1001, 1900) [500]",
+		"message [1, 1001) [500]",
+		"message [1001, 1900) [500]",
+		"pre_commit [1, 1001) [500]",
+		"pre_commit [1001, 1900) [500]",
+	}, db.pruned)
+}
@@ -0,0 +1,35 @@
+package pruning
+
+const (
+	TableBlock          = "block"
+	TablePreCommit      = "pre_commit"
+	TableTransaction    = "transaction"
+	TableMessage        = "message"
+	TableAddressMessage = "address_message"
+)
+
+// prunableTables contains the tables that can be pruned, sorted so that each table comes before the tables
+// it references. This way, rows are always deleted before the rows they reference.
+var prunableTables = []string{
+	TableAddressMessage,
+	TableMessage,
+	TableTransaction,
+	TablePreCommit,
+	TableBlock,
+}
+
+// referencedTables contains, for each table, the table that its rows reference
+var referencedTables = map[string]string{
+	TableMessage:     TableTransaction,
+	TableTransaction: TableBlock,
+}
+
+// isPrunableTable tells whether the given table can be pruned
+func isPrunableTable(table string) bool {
+	for _, t := range prunableTables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
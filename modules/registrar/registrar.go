@@ -1,11 +1,13 @@
 package registrar
 
 import (
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/forbole/juno/v5/node"
 
 	"github.com/forbole/juno/v5/modules/telemetry"
+	"github.com/forbole/juno/v5/modules/tracing"
 
 	"github.com/forbole/juno/v5/logging"
 
@@ -15,6 +17,8 @@ import (
 
 	"github.com/forbole/juno/v5/modules"
 	"github.com/forbole/juno/v5/modules/messages"
+	"github.com/forbole/juno/v5/modules/plugins"
+	"github.com/forbole/juno/v5/modules/sinks"
 
 	"github.com/forbole/juno/v5/database"
 )
@@ -26,22 +30,37 @@ type Context struct {
 	Database   database.Database
 	Proxy      node.Node
 	Logger     logging.Logger
+
+	// Codec allows modules to decode the chain messages, and can be set using WithCodec
+	Codec codec.Codec
+
+	// ModuleStore allows modules to store their own state inside the database.
+	// Each module should call ModuleStore.Namespace to get a store scoped to its own keys.
+	ModuleStore *database.ModuleStore
 }
 
 // NewContext allows to build a new Context instance
 func NewContext(
 	parsingConfig config.Config, sdkConfig *sdk.Config,
-	database database.Database, proxy node.Node, logger logging.Logger,
+	db database.Database, proxy node.Node, logger logging.Logger,
 ) Context {
 	return Context{
 		JunoConfig: parsingConfig,
 		SDKConfig:  sdkConfig,
-		Database:   database,
+		Database:   db,
 		Proxy:      proxy,
 		Logger:     logger,
+
+		ModuleStore: database.NewModuleStore(db),
 	}
 }
 
+// WithCodec returns a copy of this Context using the given codec, which allows modules to decode the chain messages
+func (ctx Context) WithCodec(cdc codec.Codec) Context {
+	ctx.Codec = cdc
+	return ctx
+}
+
 // Registrar represents a modules registrar. This allows to build a list of modules that can later be used by
 // specifying their names inside the TOML configuration file.
 type Registrar interface {
@@ -84,8 +103,11 @@ func NewDefaultRegistrar(parser messages.MessageAddressesParser) *DefaultRegistr
 func (r *DefaultRegistrar) BuildModules(ctx Context) modules.Modules {
 	return modules.Modules{
 		pruning.NewModule(ctx.JunoConfig, ctx.Database, ctx.Logger),
-		messages.NewModule(r.parser, ctx.Database),
+		messages.NewModule(r.parser, ctx.Database).WithConfig(ctx.JunoConfig).WithCodec(ctx.Codec),
 		telemetry.NewModule(ctx.JunoConfig),
+		tracing.NewModule(ctx.JunoConfig),
+		plugins.NewModule(ctx.JunoConfig, ctx.Proxy, ctx.Database, ctx.Logger),
+		sinks.NewModule(ctx.JunoConfig, ctx.Proxy, ctx.Database, r.parser, ctx.Logger),
 	}
 }
 
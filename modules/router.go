@@ -0,0 +1,133 @@
+package modules
+
+import (
+	"sort"
+
+	"github.com/forbole/juno/v5/types"
+)
+
+// MessageRouter allows to quickly get the modules that should handle a message of a given type,
+// based on the types declared by the modules implementing the MessageFilter interface
+type MessageRouter struct {
+	// routes contains, for each declared message type, the modules that should handle it in order
+	routes map[string][]Module
+
+	// catchAll contains the modules that should handle all the messages in order
+	catchAll []Module
+}
+
+// NewMessageRouter builds a new MessageRouter instance for the given modules.
+// Only the modules implementing MessageModule are taken into account.
+func NewMessageRouter(mods []Module) *MessageRouter {
+	var messageModules []Module
+	for _, module := range mods {
+		if _, ok := module.(MessageModule); ok {
+			messageModules = append(messageModules, module)
+		}
+	}
+
+	// Get all the declared message types
+	routes := map[string][]Module{}
+	for _, module := range messageModules {
+		if filter, ok := module.(MessageFilter); ok {
+			for _, msgType := range filter.MessageTypes() {
+				routes[msgType] = nil
+			}
+		}
+	}
+
+	// Build the routes keeping the modules order
+	var catchAll []Module
+	for _, module := range messageModules {
+		if !filtersMessages(module) {
+			catchAll = append(catchAll, module)
+		}
+
+		for msgType := range routes {
+			if HandlesMessage(module, msgType) {
+				routes[msgType] = append(routes[msgType], module)
+			}
+		}
+	}
+
+	return &MessageRouter{
+		routes:   routes,
+		catchAll: catchAll,
+	}
+}
+
+// Modules returns the modules that should handle a message having the given type, in order
+func (r *MessageRouter) Modules(msgType string) []Module {
+	if mods, ok := r.routes[msgType]; ok {
+		return mods
+	}
+	return r.catchAll
+}
+
+// MessageTypes returns all the message types that have been declared by at least one module, sorted alphabetically
+func (r *MessageRouter) MessageTypes() []string {
+	msgTypes := make([]string, 0, len(r.routes))
+	for msgType := range r.routes {
+		msgTypes = append(msgTypes, msgType)
+	}
+	sort.Strings(msgTypes)
+	return msgTypes
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// filtersMessages tells whether the given module declares the message types it handles
+func filtersMessages(module Module) bool {
+	filter, ok := module.(MessageFilter)
+	return ok && len(filter.MessageTypes()) > 0
+}
+
+// HandlesMessage tells whether the given module should handle a message having the given type
+func HandlesMessage(module Module, msgType string) bool {
+	if !filtersMessages(module) {
+		return true
+	}
+
+	for _, handledType := range module.(MessageFilter).MessageTypes() {
+		if handledType == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+// HandlesEvent tells whether the given module should handle an event having the given type
+func HandlesEvent(module EventModule, eventType string) bool {
+	eventTypes := module.EventTypes()
+	if len(eventTypes) == 0 {
+		return true
+	}
+
+	for _, handledType := range eventTypes {
+		if handledType == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// HandlesTx tells whether the given module should handle the given transaction, based on the events it emitted
+func HandlesTx(module Module, tx *types.Transaction) bool {
+	filter, ok := module.(MessageFilter)
+	if !ok || len(filter.TxEventTypes()) == 0 {
+		return true
+	}
+
+	if tx.TxResponse == nil || tx.TxResponse.TxResponse == nil {
+		return false
+	}
+
+	for _, eventType := range filter.TxEventTypes() {
+		for _, event := range tx.Events {
+			if event.Type == eventType {
+				return true
+			}
+		}
+	}
+	return false
+}
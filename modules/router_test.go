@@ -0,0 +1,97 @@
+package modules_test
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/types"
+)
+
+// mockMessageModule represents a modules.MessageModule that optionally filters the messages it handles
+type mockMessageModule struct {
+	name         string
+	msgTypes     []string
+	txEventTypes []string
+}
+
+func (m mockMessageModule) Name() string {
+	return m.name
+}
+
+func (m mockMessageModule) HandleMsg(_ int, _ types.Message, _ *types.Transaction) error {
+	return nil
+}
+
+func (m mockMessageModule) HandleTx(_ *types.Transaction) error {
+	return nil
+}
+
+func (m mockMessageModule) MessageTypes() []string {
+	return m.msgTypes
+}
+
+func (m mockMessageModule) TxEventTypes() []string {
+	return m.txEventTypes
+}
+
+func TestMessageRouter_Modules(t *testing.T) {
+	bank := mockMessageModule{name: "bank", msgTypes: []string{"/cosmos.bank.v1beta1.MsgSend"}}
+	messages := mockMessageModule{name: "messages"}
+	gov := mockMessageModule{name: "gov", msgTypes: []string{"/cosmos.gov.v1.MsgVote", "/cosmos.gov.v1.MsgDeposit"}}
+
+	router := modules.NewMessageRouter([]modules.Module{
+		bank,
+		messages,
+		mockModule{name: "auth"},
+		gov,
+	})
+
+	require.Equal(t,
+		[]string{"/cosmos.bank.v1beta1.MsgSend", "/cosmos.gov.v1.MsgDeposit", "/cosmos.gov.v1.MsgVote"},
+		router.MessageTypes(),
+	)
+	require.Equal(t, []modules.Module{bank, messages}, router.Modules("/cosmos.bank.v1beta1.MsgSend"))
+	require.Equal(t, []modules.Module{messages, gov}, router.Modules("/cosmos.gov.v1.MsgVote"))
+	require.Equal(t, []modules.Module{messages}, router.Modules("/cosmos.staking.v1beta1.MsgDelegate"))
+}
+
+func TestHandlesTx(t *testing.T) {
+	tx := &types.Transaction{
+		TxResponse: &types.TxResponse{
+			TxResponse: &sdk.TxResponse{
+				Events: []abci.Event{{Type: "transfer"}},
+			},
+		},
+	}
+
+	require.True(t, modules.HandlesTx(mockMessageModule{name: "messages"}, tx))
+	require.True(t, modules.HandlesTx(mockMessageModule{name: "bank", txEventTypes: []string{"coin_received", "transfer"}}, tx))
+	require.False(t, modules.HandlesTx(mockMessageModule{name: "gov", txEventTypes: []string{"proposal_vote"}}, tx))
+}
+
+// mockEventModule represents a modules.EventModule that optionally filters the events it handles
+type mockEventModule struct {
+	eventTypes []string
+}
+
+func (m mockEventModule) Name() string {
+	return "events"
+}
+
+func (m mockEventModule) EventTypes() []string {
+	return m.eventTypes
+}
+
+func (m mockEventModule) HandleEvent(_ int64, _ modules.EventSource, _ string, _ abci.Event) error {
+	return nil
+}
+
+func TestHandlesEvent(t *testing.T) {
+	require.True(t, modules.HandlesEvent(mockEventModule{}, "transfer"))
+	require.True(t, modules.HandlesEvent(mockEventModule{eventTypes: []string{"slash", "transfer"}}, "transfer"))
+	require.False(t, modules.HandlesEvent(mockEventModule{eventTypes: []string{"slash"}}, "transfer"))
+}
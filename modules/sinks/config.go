@@ -0,0 +1,137 @@
+package sinks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/forbole/juno/v5/types/config"
+)
+
+var (
+	_ config.ModuleConfig = &Config{}
+)
+
+func init() {
+	config.RegisterModuleConfig(ModuleName, "sinks", func() config.ModuleConfig {
+		return DefaultConfig()
+	})
+}
+
+// Config represents the configuration of the sinks module
+type Config struct {
+	Sinks []SinkConfig `yaml:"sinks"`
+
+	// RedeliveryInterval represents the interval at which the heights that could not be delivered are sent again
+	RedeliveryInterval time.Duration `yaml:"redelivery_interval"`
+}
+
+// DefaultConfig returns the default Config instance
+func DefaultConfig() *Config {
+	return &Config{
+		RedeliveryInterval: time.Minute,
+	}
+}
+
+// Validate implements config.ModuleConfig
+func (c *Config) Validate() error {
+	if c.RedeliveryInterval <= 0 {
+		return fmt.Errorf("redelivery_interval must be greater than 0")
+	}
+
+	names := map[string]bool{}
+	for _, sink := range c.Sinks {
+		err := sink.Validate()
+		if err != nil {
+			return err
+		}
+
+		if names[sink.Name] {
+			return fmt.Errorf("duplicated sink name: %s", sink.Name)
+		}
+		names[sink.Name] = true
+	}
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// SinkConfig contains the configuration of a single sink
+type SinkConfig struct {
+	// Name is used to identify the sink inside the logs and to store its cursor
+	Name string `yaml:"name"`
+
+	// Type represents the type of the sink (eg. "webhook" or "file")
+	Type string `yaml:"type"`
+
+	// URL represents the endpoint to which the envelopes are sent (used by the webhook sink)
+	URL     string            `yaml:"url,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// Path represents the file to which the envelopes are appended (used by the file sink)
+	Path string `yaml:"path,omitempty"`
+
+	// Options contains the options used by the custom sinks types
+	Options map[string]string `yaml:"options,omitempty"`
+
+	// Timeout represents the max time a single delivery can take
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+
+	// MaxRetries represents the max number of times a delivery is retried before marking the height
+	// as failed, so that it is delivered again later on
+	MaxRetries    int           `yaml:"max_retries,omitempty"`
+	RetryInterval time.Duration `yaml:"retry_interval,omitempty"`
+
+	// Kinds contains the kinds of the envelopes (block, tx, message or event) that should be delivered.
+	// If empty, all the envelopes are delivered.
+	Kinds []string `yaml:"kinds,omitempty"`
+
+	// MessageTypes contains the types of the messages that should be delivered.
+	// Transactions are delivered only if they contain at least one message having one of these types.
+	// If empty, all the messages are delivered.
+	MessageTypes []string `yaml:"message_types,omitempty"`
+
+	// Addresses contains the addresses of interest. If not empty, only the transactions and the messages
+	// that involve at least one of these addresses are delivered.
+	Addresses []string `yaml:"addresses,omitempty"`
+}
+
+// Validate returns an error if the sink configuration is not valid
+func (c SinkConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("sink name cannot be empty")
+	}
+
+	if _, found := getSinkBuilder(c.Type); !found {
+		return fmt.Errorf("invalid type of sink %s: %s", c.Name, c.Type)
+	}
+
+	for _, kind := range c.Kinds {
+		switch kind {
+		case KindBlock, KindTx, KindMessage, KindEvent:
+		default:
+			return fmt.Errorf("invalid envelope kind for sink %s: %s", c.Name, kind)
+		}
+	}
+
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("max_retries of sink %s cannot be negative", c.Name)
+	}
+
+	return nil
+}
+
+// GetTimeout returns the max time a single delivery can take
+func (c SinkConfig) GetTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 10 * time.Second
+	}
+	return c.Timeout
+}
+
+// GetRetryInterval returns the time to wait before retrying a failed delivery
+func (c SinkConfig) GetRetryInterval() time.Duration {
+	if c.RetryInterval <= 0 {
+		return time.Second
+	}
+	return c.RetryInterval
+}
@@ -0,0 +1,220 @@
+package sinks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	tmjson "github.com/cometbft/cometbft/libs/json"
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/modules/messages"
+	"github.com/forbole/juno/v5/types"
+)
+
+const (
+	KindBlock   = "block"
+	KindTx      = "tx"
+	KindMessage = "message"
+	KindEvent   = "event"
+)
+
+// Envelope represents a single piece of indexed data that is published to the sinks
+type Envelope struct {
+	Kind   string `json:"kind"`
+	Height int64  `json:"height"`
+
+	// TxHash contains the hash of the transaction (for tx, message and tx events envelopes)
+	TxHash string `json:"tx_hash,omitempty"`
+
+	// Index contains the index of the message inside the transaction (for message envelopes)
+	Index int `json:"index,omitempty"`
+
+	// Type contains the type of the message or of the event
+	Type string `json:"type,omitempty"`
+
+	// Source contains the source of the event (begin_block, end_block or tx)
+	Source string `json:"source,omitempty"`
+
+	// Addresses contains the addresses involved (for tx and message envelopes)
+	Addresses []string `json:"addresses,omitempty"`
+
+	// MessageTypes contains the types of the messages of the transaction (for tx envelopes)
+	MessageTypes []string `json:"message_types,omitempty"`
+
+	Data json.RawMessage `json:"data"`
+}
+
+// buildEnvelopes builds all the envelopes of the given block, in the order in which the data has been produced
+func buildEnvelopes(
+	block *tmctypes.ResultBlock, results *tmctypes.ResultBlockResults, txs []*types.Transaction,
+	parseAddresses messages.MessageAddressesParser,
+) ([]Envelope, error) {
+	height := block.Block.Height
+
+	blockBz, err := tmjson.Marshal(block)
+	if err != nil {
+		return nil, fmt.Errorf("error while serializing block: %s", err)
+	}
+
+	envelopes := []Envelope{{Kind: KindBlock, Height: height, Data: blockBz}}
+
+	if results != nil {
+		blockEvents, err := buildEventsEnvelopes(height, modules.EventSourceBeginBlock, "", results.BeginBlockEvents)
+		if err != nil {
+			return nil, err
+		}
+		envelopes = append(envelopes, blockEvents...)
+	}
+
+	for _, tx := range txs {
+		txEnvelopes, err := buildTxEnvelopes(tx, parseAddresses)
+		if err != nil {
+			return nil, err
+		}
+		envelopes = append(envelopes, txEnvelopes...)
+	}
+
+	if results != nil {
+		blockEvents, err := buildEventsEnvelopes(height, modules.EventSourceEndBlock, "", results.EndBlockEvents)
+		if err != nil {
+			return nil, err
+		}
+		envelopes = append(envelopes, blockEvents...)
+	}
+
+	return envelopes, nil
+}
+
+// buildTxEnvelopes builds the envelopes of the given transaction, its messages and its events
+func buildTxEnvelopes(tx *types.Transaction, parseAddresses messages.MessageAddressesParser) ([]Envelope, error) {
+	height := int64(tx.Height)
+
+	addresses, err := parseAddresses(tx)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing addresses of tx %s: %s", tx.TxHash, err)
+	}
+
+	txBz, err := json.Marshal(tx)
+	if err != nil {
+		return nil, fmt.Errorf("error while serializing tx %s: %s", tx.TxHash, err)
+	}
+
+	var msgTypes []string
+	var msgEnvelopes []Envelope
+	for i, msg := range tx.Body.Messages {
+		msgAddresses, err := messages.ParseMessageAddresses(msg, tx)
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing addresses of tx %s: %s", tx.TxHash, err)
+		}
+
+		msgTypes = append(msgTypes, msg.GetType())
+		msgEnvelopes = append(msgEnvelopes, Envelope{
+			Kind:      KindMessage,
+			Height:    height,
+			TxHash:    tx.TxHash,
+			Index:     i,
+			Type:      msg.GetType(),
+			Addresses: msgAddresses,
+			Data:      msg.GetBytes(),
+		})
+	}
+
+	envelopes := []Envelope{{
+		Kind:         KindTx,
+		Height:       height,
+		TxHash:       tx.TxHash,
+		Addresses:    addresses,
+		MessageTypes: msgTypes,
+		Data:         txBz,
+	}}
+	envelopes = append(envelopes, msgEnvelopes...)
+
+	eventsEnvelopes, err := buildEventsEnvelopes(height, modules.EventSourceTx, tx.TxHash, tx.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(envelopes, eventsEnvelopes...), nil
+}
+
+// buildEventsEnvelopes builds the envelopes of the given events
+func buildEventsEnvelopes(height int64, source modules.EventSource, txHash string, events []abci.Event) ([]Envelope, error) {
+	envelopes := make([]Envelope, len(events))
+	for i, event := range events {
+		eventBz, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("error while serializing %s event: %s", event.Type, err)
+		}
+
+		envelopes[i] = Envelope{
+			Kind:   KindEvent,
+			Height: height,
+			TxHash: txHash,
+			Type:   event.Type,
+			Source: string(source),
+			Data:   eventBz,
+		}
+	}
+	return envelopes, nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// accepts tells whether the given envelope should be delivered to the sink having this configuration
+func (c SinkConfig) accepts(envelope Envelope) bool {
+	if len(c.Kinds) > 0 && !contains(c.Kinds, envelope.Kind) {
+		return false
+	}
+
+	switch envelope.Kind {
+	case KindTx:
+		return c.acceptsMessageTypes(envelope.MessageTypes...) && c.acceptsAddresses(envelope.Addresses)
+
+	case KindMessage:
+		return c.acceptsMessageTypes(envelope.Type) && c.acceptsAddresses(envelope.Addresses)
+
+	default:
+		// Blocks and events do not involve specific addresses
+		return len(c.Addresses) == 0
+	}
+}
+
+// acceptsMessageTypes tells whether at least one of the given message types should be delivered
+func (c SinkConfig) acceptsMessageTypes(msgTypes ...string) bool {
+	if len(c.MessageTypes) == 0 {
+		return true
+	}
+
+	for _, msgType := range msgTypes {
+		if contains(c.MessageTypes, msgType) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsAddresses tells whether at least one of the given addresses is of interest
+func (c SinkConfig) acceptsAddresses(addresses []string) bool {
+	if len(c.Addresses) == 0 {
+		return true
+	}
+
+	for _, address := range addresses {
+		if contains(c.Addresses, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains tells whether the given slice contains the given value
+func contains(slice []string, value string) bool {
+	for _, item := range slice {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package sinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+
+	"github.com/forbole/juno/v5/types"
+)
+
+// HandleBlock implements modules.BlockModule.
+// All the envelopes of the block, its transactions, messages and events are delivered at once, so that each
+// height is either fully delivered or delivered again later on.
+func (m *Module) HandleBlock(
+	block *tmctypes.ResultBlock, results *tmctypes.ResultBlockResults, txs []*types.Transaction, _ *tmctypes.ResultValidators,
+) error {
+	if len(m.sinks) == 0 {
+		return nil
+	}
+
+	envelopes, err := buildEnvelopes(block, results, txs, m.parseAddresses)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, client := range m.sinks {
+		err = m.deliver(client, block.Block.Height, envelopes)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver publishes the given envelopes of the given height to the given sink, retrying if needed.
+// The height is then marked as either delivered or failed, so that it can be delivered again later on.
+func (m *Module) deliver(client *sinkClient, height int64, envelopes []Envelope) error {
+	var filtered []Envelope
+	for _, envelope := range envelopes {
+		if client.cfg.accepts(envelope) {
+			filtered = append(filtered, envelope)
+		}
+	}
+
+	var deliveryErr error
+	if len(filtered) > 0 {
+		deliveryErr = publishWithRetries(client, filtered)
+	}
+
+	err := client.tracker.Done(height, deliveryErr != nil)
+	if err != nil {
+		return fmt.Errorf("error while storing sink %s progress: %s", client.cfg.Name, err)
+	}
+
+	if deliveryErr != nil {
+		return fmt.Errorf("error while delivering height %d to sink %s: %s", height, client.cfg.Name, deliveryErr)
+	}
+
+	return nil
+}
+
+// publishWithRetries publishes the given envelopes to the given sink, retrying up to the configured number of times
+func publishWithRetries(client *sinkClient, envelopes []Envelope) error {
+	var err error
+	for attempt := 0; attempt <= client.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(client.cfg.GetRetryInterval() * time.Duration(attempt))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), client.cfg.GetTimeout())
+		err = client.sink.Publish(ctx, envelopes)
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// redeliverFailedHeights tries to deliver again all the heights that could not be delivered
+func (m *Module) redeliverFailedHeights() {
+	for _, client := range m.sinks {
+		for _, height := range client.tracker.FailedHeights() {
+			err := m.redeliver(client, height)
+			if err != nil {
+				m.logger.Error("error while redelivering height", "err", err, "sink", client.cfg.Name, "height", height)
+				break
+			}
+		}
+	}
+}
+
+// redeliver gets the data of the given height from the node, and delivers it again to the given sink
+func (m *Module) redeliver(client *sinkClient, height int64) error {
+	block, err := m.node.Block(height)
+	if err != nil {
+		return fmt.Errorf("error while getting block: %s", err)
+	}
+
+	results, err := m.node.BlockResults(height)
+	if err != nil {
+		return fmt.Errorf("error while getting block results: %s", err)
+	}
+
+	txs, err := m.node.Txs(block)
+	if err != nil {
+		return fmt.Errorf("error while getting transactions: %s", err)
+	}
+
+	envelopes, err := buildEnvelopes(block, results, txs, m.parseAddresses)
+	if err != nil {
+		return err
+	}
+
+	return m.deliver(client, height, envelopes)
+}
@@ -0,0 +1,177 @@
+package sinks
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-co-op/gocron"
+
+	"github.com/forbole/juno/v5/database"
+	"github.com/forbole/juno/v5/logging"
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/modules/messages"
+	"github.com/forbole/juno/v5/modules/progress"
+	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+const (
+	ModuleName = "sinks"
+)
+
+var (
+	_ modules.Module                   = &Module{}
+	_ modules.InitializableModule      = &Module{}
+	_ modules.StartableModule          = &Module{}
+	_ modules.StoppableModule          = &Module{}
+	_ modules.BlockModule              = &Module{}
+	_ modules.PeriodicOperationsModule = &Module{}
+)
+
+// Module represents the module that publishes the indexed data to external sinks (eg. webhooks)
+type Module struct {
+	junoCfg config.Config
+	cfg     *Config
+
+	node           node.Node
+	db             database.Database
+	store          *database.ModuleStore
+	parseAddresses messages.MessageAddressesParser
+	logger         logging.Logger
+
+	sinks []*sinkClient
+}
+
+// sinkClient contains a sink along with its configuration and the tracker of its delivered heights
+type sinkClient struct {
+	cfg     SinkConfig
+	sink    Sink
+	tracker *progress.Tracker
+}
+
+// NewModule returns a new Module instance.
+// The module configuration is parsed and validated when calling Init.
+func NewModule(
+	cfg config.Config, node node.Node, db database.Database,
+	parseAddresses messages.MessageAddressesParser, logger logging.Logger,
+) *Module {
+	return &Module{
+		junoCfg:        cfg,
+		node:           node,
+		db:             db,
+		store:          database.NewModuleStore(db).Namespace(ModuleName),
+		parseAddresses: parseAddresses,
+		logger:         logger,
+	}
+}
+
+// Name implements modules.Module
+func (m *Module) Name() string {
+	return ModuleName
+}
+
+// Init implements modules.InitializableModule
+func (m *Module) Init() error {
+	cfg, err := m.junoCfg.GetModuleConfig(ModuleName)
+	if err != nil {
+		return err
+	}
+	m.cfg = cfg.(*Config)
+
+	m.sinks = nil
+	for _, sinkCfg := range m.cfg.Sinks {
+		builder, _ := getSinkBuilder(sinkCfg.Type)
+		sink, err := builder(sinkCfg)
+		if err != nil {
+			return fmt.Errorf("error while building sink %s: %s", sinkCfg.Name, err)
+		}
+
+		m.sinks = append(m.sinks, &sinkClient{
+			cfg:     sinkCfg,
+			sink:    sink,
+			tracker: progress.NewTracker(m.store, sinkCfg.Name),
+		})
+	}
+
+	return nil
+}
+
+// Start implements modules.StartableModule.
+// The progress of each sink is resumed, so that all the heights that might have been stored without being
+// delivered before the last shutdown are delivered again.
+func (m *Module) Start() error {
+	if len(m.sinks) == 0 {
+		return nil
+	}
+
+	lastHeight, err := m.db.GetLastBlockHeight()
+	if err != nil {
+		return fmt.Errorf("error while getting last block height: %s", err)
+	}
+
+	for _, client := range m.sinks {
+		err = client.tracker.Resume(lastHeight)
+		if err != nil {
+			return fmt.Errorf("error while resuming sink %s progress: %s", client.cfg.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Stop implements modules.StoppableModule
+func (m *Module) Stop() error {
+	var errs []error
+	for _, client := range m.sinks {
+		err := client.sink.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error while closing sink %s: %s", client.cfg.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	if len(m.sinks) == 0 {
+		return nil
+	}
+
+	_, err := scheduler.Every(m.cfg.RedeliveryInterval).SingletonMode().Do(func() {
+		m.redeliverFailedHeights()
+	})
+	if err != nil {
+		return fmt.Errorf("error while scheduling sinks redelivery: %s", err)
+	}
+
+	return nil
+}
+
+// GetCursor returns the height up to which all the heights have either been delivered to the sink
+// having the given name or marked as failed
+func (m *Module) GetCursor(sinkName string) (int64, error) {
+	client, err := m.getSinkClient(sinkName)
+	if err != nil {
+		return 0, err
+	}
+	return client.tracker.Cursor(), nil
+}
+
+// GetFailedHeights returns the heights that could not be delivered to the sink having the given name yet
+func (m *Module) GetFailedHeights(sinkName string) ([]int64, error) {
+	client, err := m.getSinkClient(sinkName)
+	if err != nil {
+		return nil, err
+	}
+	return client.tracker.FailedHeights(), nil
+}
+
+// getSinkClient returns the client of the sink having the given name
+func (m *Module) getSinkClient(sinkName string) (*sinkClient, error) {
+	for _, client := range m.sinks {
+		if client.cfg.Name == sinkName {
+			return client, nil
+		}
+	}
+	return nil, fmt.Errorf("sink %s not found", sinkName)
+}
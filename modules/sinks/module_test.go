@@ -0,0 +1,159 @@
+package sinks_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/database/testutils"
+	"github.com/forbole/juno/v5/logging"
+	"github.com/forbole/juno/v5/modules/messages"
+	"github.com/forbole/juno/v5/modules/sinks"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+// failingSink represents a sinks.Sink that fails for all the envelopes of the given heights
+type failingSink struct {
+	failingHeights map[int64]bool
+	heights        []int64
+}
+
+func (s *failingSink) Publish(_ context.Context, envelopes []sinks.Envelope) error {
+	if s.failingHeights[envelopes[0].Height] {
+		return fmt.Errorf("unlucky height")
+	}
+	s.heights = append(s.heights, envelopes[0].Height)
+	return nil
+}
+
+func (s *failingSink) Close() error {
+	return nil
+}
+
+// testDb represents a database that contains the given last block height
+type testDb struct {
+	*testutils.ModuleStoreDb
+	lastHeight int64
+}
+
+func (db *testDb) GetLastBlockHeight() (int64, error) {
+	return db.lastHeight, nil
+}
+
+func TestModule_HandleBlock(t *testing.T) {
+	failing := &failingSink{failingHeights: map[int64]bool{13: true}}
+	sinks.RegisterSinkType("failing", func(_ sinks.SinkConfig) (sinks.Sink, error) {
+		return failing, nil
+	})
+
+	path := filepath.Join(t.TempDir(), "envelopes.jsonl")
+	junoCfg, err := config.DefaultConfigParser([]byte(fmt.Sprintf(`
+chain:
+  modules: [ sinks ]
+sinks:
+  redelivery_interval: 1m
+  sinks:
+    - name: file
+      type: file
+      path: %s
+      kinds: [ block ]
+    - name: failing
+      type: failing
+      max_retries: 1
+      retry_interval: 1ms
+`, path)))
+	require.NoError(t, err)
+
+	db := &testDb{ModuleStoreDb: testutils.NewModuleStoreDb(), lastHeight: 11}
+	module := sinks.NewModule(junoCfg, nil, db, messages.JoinMessageParsers(), logging.DefaultLogger())
+	require.NoError(t, module.Init())
+	require.NoError(t, module.Start())
+	defer module.Stop()
+
+	// Handle some blocks
+	for _, height := range []int64{12, 13, 14} {
+		block := &tmctypes.ResultBlock{Block: &tmtypes.Block{Header: tmtypes.Header{Height: height}}}
+		err = module.HandleBlock(block, &tmctypes.ResultBlockResults{}, nil, &tmctypes.ResultValidators{})
+		if height == 13 {
+			require.ErrorContains(t, err, "unlucky height")
+		} else {
+			require.NoError(t, err)
+		}
+	}
+	require.Equal(t, []int64{12, 14}, failing.heights)
+
+	// The file sink should contain all the blocks
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var heights []int64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var envelope sinks.Envelope
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &envelope))
+		require.Equal(t, sinks.KindBlock, envelope.Kind)
+		heights = append(heights, envelope.Height)
+	}
+	require.Equal(t, []int64{12, 13, 14}, heights)
+
+	// Cursors and failed heights should be tracked
+	cursor, err := module.GetCursor("failing")
+	require.NoError(t, err)
+	require.Equal(t, int64(14), cursor)
+
+	failedHeights, err := module.GetFailedHeights("failing")
+	require.NoError(t, err)
+	require.Equal(t, []int64{13}, failedHeights)
+
+	failedHeights, err = module.GetFailedHeights("file")
+	require.NoError(t, err)
+	require.Empty(t, failedHeights)
+
+	// Heights failing out of order should all be tracked
+	failing.failingHeights = map[int64]bool{16: true, 15: true}
+	for _, height := range []int64{16, 15} {
+		block := &tmctypes.ResultBlock{Block: &tmtypes.Block{Header: tmtypes.Header{Height: height}}}
+		err = module.HandleBlock(block, &tmctypes.ResultBlockResults{}, nil, &tmctypes.ResultValidators{})
+		require.ErrorContains(t, err, "unlucky height")
+	}
+
+	failedHeights, err = module.GetFailedHeights("failing")
+	require.NoError(t, err)
+	require.Equal(t, []int64{13, 15, 16}, failedHeights)
+
+	// Delivering a failed height again should remove it from the failed heights
+	failing.failingHeights = nil
+	block := &tmctypes.ResultBlock{Block: &tmtypes.Block{Header: tmtypes.Header{Height: 15}}}
+	require.NoError(t, module.HandleBlock(block, &tmctypes.ResultBlockResults{}, nil, &tmctypes.ResultValidators{}))
+
+	failedHeights, err = module.GetFailedHeights("failing")
+	require.NoError(t, err)
+	require.Equal(t, []int64{13, 16}, failedHeights)
+
+	// After a restart, the failed heights should be kept and the heights stored by Juno after the cursor
+	// should be delivered again
+	require.NoError(t, module.Stop())
+	db.lastHeight = 18
+
+	module = sinks.NewModule(junoCfg, nil, db, messages.JoinMessageParsers(), logging.DefaultLogger())
+	require.NoError(t, module.Init())
+	require.NoError(t, module.Start())
+	defer module.Stop()
+
+	cursor, err = module.GetCursor("failing")
+	require.NoError(t, err)
+	require.Equal(t, int64(18), cursor)
+
+	failedHeights, err = module.GetFailedHeights("failing")
+	require.NoError(t, err)
+	require.Equal(t, []int64{13, 16, 17, 18}, failedHeights)
+}
@@ -0,0 +1,173 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+const (
+	TypeWebhook = "webhook"
+	TypeFile    = "file"
+)
+
+// Sink represents a destination to which the envelopes are published
+type Sink interface {
+	// Publish delivers the given envelopes, which all belong to the same height.
+	// If an error is returned, all the envelopes will be published again later on.
+	Publish(ctx context.Context, envelopes []Envelope) error
+
+	// Close releases all the resources used by the sink
+	Close() error
+}
+
+// SinkBuilder represents a function that allows to build a Sink from its configuration
+type SinkBuilder func(cfg SinkConfig) (Sink, error)
+
+var (
+	sinkBuildersMu sync.RWMutex
+	sinkBuilders   = map[string]SinkBuilder{
+		TypeWebhook: newWebhookSink,
+		TypeFile:    newFileSink,
+	}
+)
+
+// RegisterSinkType registers the given builder to be used for the sinks having the given type.
+// This allows to support other destinations (eg. NATS or Kafka) without having to change this module.
+// NOTE. This must be called before reading the configuration.
+func RegisterSinkType(sinkType string, builder SinkBuilder) {
+	sinkBuildersMu.Lock()
+	defer sinkBuildersMu.Unlock()
+	sinkBuilders[sinkType] = builder
+}
+
+// getSinkBuilder returns the builder of the sinks having the given type
+func getSinkBuilder(sinkType string) (SinkBuilder, bool) {
+	sinkBuildersMu.RLock()
+	defer sinkBuildersMu.RUnlock()
+
+	builder, found := sinkBuilders[sinkType]
+	return builder, found
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+var (
+	_ Sink = &webhookSink{}
+)
+
+// webhookSink represents a Sink that sends the envelopes of each height as a JSON array to an HTTP endpoint
+type webhookSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// newWebhookSink builds a new webhookSink instance
+func newWebhookSink(cfg SinkConfig) (Sink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("url of webhook sink %s cannot be empty", cfg.Name)
+	}
+
+	return &webhookSink{
+		url:     cfg.URL,
+		headers: cfg.Headers,
+		client:  &http.Client{},
+	}, nil
+}
+
+// Publish implements Sink
+func (s *webhookSink) Publish(ctx context.Context, envelopes []Envelope) error {
+	bz, err := json.Marshal(envelopes)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("webhook returned status %d: %s", res.StatusCode, body)
+	}
+
+	return nil
+}
+
+// Close implements Sink
+func (s *webhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+var (
+	_ Sink = &fileSink{}
+)
+
+// fileSink represents a Sink that appends each envelope as a JSON line to a local file
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// newFileSink builds a new fileSink instance
+func newFileSink(cfg SinkConfig) (Sink, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("path of file sink %s cannot be empty", cfg.Name)
+	}
+
+	file, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error while opening file of sink %s: %s", cfg.Name, err)
+	}
+
+	return &fileSink{
+		file: file,
+	}, nil
+}
+
+// Publish implements Sink
+func (s *fileSink) Publish(_ context.Context, envelopes []Envelope) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, envelope := range envelopes {
+		err := encoder.Encode(envelope)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.file.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// Close implements Sink
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
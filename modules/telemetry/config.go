@@ -1,19 +1,67 @@
 package telemetry
 
-import "gopkg.in/yaml.v3"
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/forbole/juno/v5/types/config"
+)
+
+var (
+	_ config.ModuleConfig = &Config{}
+)
+
+func init() {
+	config.RegisterModuleConfig(ModuleName, "telemetry", func() config.ModuleConfig {
+		return DefaultConfig()
+	})
+}
 
 // Config represents the configuration for the telemetry module
 type Config struct {
 	Port uint `yaml:"port"`
+
+	// MaxIndexingLag represents the max number of heights the indexed chain can be behind the chain head
+	// for Juno to be considered ready
+	MaxIndexingLag int64 `yaml:"max_indexing_lag"`
+
+	// HealthCheckTimeout represents the max amount of time the health checks can take before being considered failed
+	HealthCheckTimeout time.Duration `yaml:"health_check_timeout"`
 }
 
 // NewConfig allows to build a new Config instance
-func NewConfig(port uint) *Config {
+func NewConfig(port uint, maxIndexingLag int64, healthCheckTimeout time.Duration) *Config {
 	return &Config{
-		Port: port,
+		Port:               port,
+		MaxIndexingLag:     maxIndexingLag,
+		HealthCheckTimeout: healthCheckTimeout,
 	}
 }
 
+// DefaultConfig returns the default Config instance
+func DefaultConfig() *Config {
+	return NewConfig(5000, 50, 5*time.Second)
+}
+
+// Validate implements config.ModuleConfig
+func (c *Config) Validate() error {
+	if c.Port == 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid port: %d", c.Port)
+	}
+
+	if c.MaxIndexingLag < 0 {
+		return fmt.Errorf("max_indexing_lag cannot be negative")
+	}
+
+	if c.HealthCheckTimeout <= 0 {
+		return fmt.Errorf("health_check_timeout must be greater than zero")
+	}
+
+	return nil
+}
+
 // ParseConfig allows to parse a byte array as a Config instance
 func ParseConfig(bytes []byte) (*Config, error) {
 	type T struct {
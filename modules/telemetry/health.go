@@ -0,0 +1,212 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/forbole/juno/v5/modules"
+)
+
+const (
+	checkDatabase = "database"
+	checkNode     = "node"
+	checkLag      = "indexing_lag"
+)
+
+// healthResponse represents the response of the health and readiness endpoints
+type healthResponse struct {
+	Healthy bool              `json:"healthy"`
+	Checks  map[string]string `json:"checks"`
+}
+
+// statusResponse represents the response of the status endpoint
+type statusResponse struct {
+	Height         int64             `json:"height"`
+	ChainHeight    int64             `json:"chain_height"`
+	IndexingLag    int64             `json:"indexing_lag"`
+	WorkersHeights map[int]int64     `json:"workers_heights"`
+	Modules        []string          `json:"modules"`
+	QueueDepth     int               `json:"queue_depth"`
+	Errors         map[string]string `json:"errors,omitempty"`
+}
+
+// newRouter returns a new router serving the Prometheus metrics along with the health, readiness and status endpoints
+func (m *Module) newRouter() *mux.Router {
+	router := newRouter()
+	router.HandleFunc("/healthz", m.handleHealthz).Methods(http.MethodGet)
+	router.HandleFunc("/readyz", m.handleReadyz).Methods(http.MethodGet)
+	router.HandleFunc("/status", m.handleStatus).Methods(http.MethodGet)
+	return router
+}
+
+// handleHealthz tells whether the database is reachable and all the modules are healthy
+func (m *Module) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	results := m.runChecks(m.healthChecks())
+	writeHealthResponse(w, results)
+}
+
+// handleReadyz tells whether Juno is healthy, the node is reachable and the indexed chain
+// is not too far behind the chain head
+func (m *Module) handleReadyz(w http.ResponseWriter, _ *http.Request) {
+	checks := m.healthChecks()
+	checks[checkNode] = m.checkNode
+
+	results := m.runChecks(checks)
+	dbResult, nodeResult := results[checkDatabase], results[checkNode]
+	if dbResult.err == nil && nodeResult.err == nil {
+		results[checkLag] = checkResult{err: m.checkLag(dbResult.height, nodeResult.height)}
+	}
+	writeHealthResponse(w, results)
+}
+
+// handleStatus returns the current parsing status
+func (m *Module) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	res := statusResponse{
+		WorkersHeights: m.status.GetWorkersHeights(),
+		Modules:        modules.Modules(m.mods).Names(),
+		QueueDepth:     m.status.GetQueueDepth(),
+		Errors:         map[string]string{},
+	}
+
+	results := m.runChecks(map[string]checkFunc{
+		checkDatabase: m.checkDatabase,
+		checkNode:     m.checkNode,
+	})
+
+	for name, result := range results {
+		if result.err != nil {
+			res.Errors[name] = result.err.Error()
+		}
+	}
+
+	res.Height = results[checkDatabase].height
+	res.ChainHeight = results[checkNode].height
+	if len(res.Errors) == 0 {
+		res.IndexingLag = res.ChainHeight - res.Height
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// checkFunc represents a single check, returning the height it has read (if any) or an error if it failed
+type checkFunc func() (height int64, err error)
+
+// checkResult contains the result of a checkFunc
+type checkResult struct {
+	height int64
+	err    error
+}
+
+// healthChecks returns the checks telling whether the database is reachable and all the modules are healthy,
+// indexed by the name of the checked component
+func (m *Module) healthChecks() map[string]checkFunc {
+	checks := map[string]checkFunc{
+		checkDatabase: m.checkDatabase,
+	}
+
+	for _, module := range m.mods {
+		if checkable, ok := module.(modules.HealthCheckModule); ok {
+			checks[fmt.Sprintf("module/%s", module.Name())] = func() (int64, error) {
+				return 0, checkable.HealthCheck()
+			}
+		}
+	}
+
+	return checks
+}
+
+// runChecks runs concurrently all the given checks, returning their results indexed by the same names.
+// Checks that do not complete within the configured timeout are considered failed.
+func (m *Module) runChecks(checks map[string]checkFunc) map[string]checkResult {
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.HealthCheckTimeout)
+	defer cancel()
+
+	type namedResult struct {
+		name string
+		checkResult
+	}
+
+	// Use a buffered channel so that checks completing after the timeout do not block forever
+	resultsCh := make(chan namedResult, len(checks))
+	for name, check := range checks {
+		go func(name string, check checkFunc) {
+			height, err := check()
+			resultsCh <- namedResult{name: name, checkResult: checkResult{height: height, err: err}}
+		}(name, check)
+	}
+
+	results := map[string]checkResult{}
+	for len(results) < len(checks) {
+		select {
+		case result := <-resultsCh:
+			results[result.name] = result.checkResult
+
+		case <-ctx.Done():
+			for name := range checks {
+				if _, ok := results[name]; !ok {
+					results[name] = checkResult{err: fmt.Errorf("check timed out after %s", m.cfg.HealthCheckTimeout)}
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+// checkDatabase returns the last height stored inside the database, or an error if it cannot be read
+func (m *Module) checkDatabase() (int64, error) {
+	if m.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+	return m.db.GetLastBlockHeight()
+}
+
+// checkNode returns the latest height of the chain, or an error if the node cannot be reached
+func (m *Module) checkNode() (int64, error) {
+	if m.node == nil {
+		return 0, fmt.Errorf("node not available")
+	}
+	return m.node.LatestHeight()
+}
+
+// checkLag returns an error if the given height is too far behind the given chain height
+func (m *Module) checkLag(height, chainHeight int64) error {
+	lag := chainHeight - height
+	if lag > m.cfg.MaxIndexingLag {
+		return fmt.Errorf("indexed height %d is %d heights behind the chain head (max %d)", height, lag, m.cfg.MaxIndexingLag)
+	}
+	return nil
+}
+
+// writeHealthResponse writes the given checks results, using the 503 status code if any of them failed
+func writeHealthResponse(w http.ResponseWriter, results map[string]checkResult) {
+	res := healthResponse{
+		Healthy: true,
+		Checks:  map[string]string{},
+	}
+
+	for name, result := range results {
+		res.Checks[name] = "ok"
+		if result.err != nil {
+			res.Healthy = false
+			res.Checks[name] = result.err.Error()
+		}
+	}
+
+	statusCode := http.StatusOK
+	if !res.Healthy {
+		statusCode = http.StatusServiceUnavailable
+	}
+	writeJSON(w, statusCode, res)
+}
+
+// writeJSON writes the given value as a JSON response having the given status code
+func writeJSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(value)
+}
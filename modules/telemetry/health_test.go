@@ -0,0 +1,138 @@
+package telemetry_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/modules/telemetry"
+	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/types"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+// mockDb represents a modules.HeightDb implementation returning a fixed last block height
+type mockDb struct {
+	height int64
+}
+
+func (db *mockDb) GetLastBlockHeight() (int64, error) {
+	return db.height, nil
+}
+
+// mockNode represents a node.Node implementation returning a fixed latest height or error
+type mockNode struct {
+	node.Node
+	height int64
+	err    error
+}
+
+func (n *mockNode) LatestHeight() (int64, error) {
+	return n.height, n.err
+}
+
+// slowModule represents a modules.HealthCheckModule implementation taking the given delay to be checked
+type slowModule struct {
+	delay time.Duration
+}
+
+func (m *slowModule) Name() string {
+	return "slow"
+}
+
+func (m *slowModule) HealthCheck() error {
+	time.Sleep(m.delay)
+	return nil
+}
+
+// getFreePort returns a port that is not currently in use
+func getFreePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// get performs a GET request to the given endpoint, returning the status code and the decoded body
+func get(t *testing.T, port int, endpoint string) (int, map[string]interface{}) {
+	res, err := http.Get(fmt.Sprintf("http://localhost:%d%s", port, endpoint))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	return res.StatusCode, body
+}
+
+func TestModule_HealthEndpoints(t *testing.T) {
+	port := getFreePort(t)
+	junoCfg, err := config.DefaultConfigParser([]byte(fmt.Sprintf(`
+chain:
+  modules: [ telemetry ]
+telemetry:
+  port: %d
+  max_indexing_lag: 10
+  health_check_timeout: 200ms
+`, port)))
+	require.NoError(t, err)
+
+	db := &mockDb{height: 95}
+	chainNode := &mockNode{height: 100}
+	slow := &slowModule{}
+	module := telemetry.NewModule(junoCfg)
+	module.SetDatabase(db)
+	module.SetNode(chainNode)
+	require.NoError(t, modules.InitModules([]modules.Module{module, slow}))
+
+	status := types.NewParsingStatus()
+	status.SetQueue(types.NewQueue(5))
+	status.SetWorkerHeight(0, 95)
+	module.SetParsingStatus(status)
+
+	require.NoError(t, module.Start())
+	defer module.Stop()
+
+	code, body := get(t, port, "/healthz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, true, body["healthy"])
+
+	code, body = get(t, port, "/readyz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "ok", body["checks"].(map[string]interface{})["indexing_lag"])
+
+	code, body = get(t, port, "/status")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(95), body["height"])
+	require.Equal(t, float64(100), body["chain_height"])
+	require.Equal(t, float64(5), body["indexing_lag"])
+	require.Equal(t, map[string]interface{}{"0": float64(95)}, body["workers_heights"])
+	require.Equal(t, []interface{}{"telemetry", "slow"}, body["modules"])
+	require.Equal(t, float64(0), body["queue_depth"])
+
+	// Being too far behind the chain head should only affect the readiness
+	db.height = 50
+	code, _ = get(t, port, "/healthz")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = get(t, port, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+
+	// Not being able to reach the node should only affect the readiness
+	chainNode.err = fmt.Errorf("connection refused")
+	code, _ = get(t, port, "/healthz")
+	require.Equal(t, http.StatusOK, code)
+	code, body = get(t, port, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "connection refused", body["checks"].(map[string]interface{})["node"])
+
+	// Checks taking too long should be considered failed
+	slow.delay = time.Second
+	code, body = get(t, port, "/healthz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "check timed out after 200ms", body["checks"].(map[string]interface{})["module/slow"])
+}
@@ -1,7 +1,19 @@
 package telemetry
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+
 	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/types"
 	"github.com/forbole/juno/v5/types/config"
 )
 
@@ -10,38 +22,116 @@ const (
 )
 
 var (
-	_ modules.Module                     = &Module{}
-	_ modules.AdditionalOperationsModule = &Module{}
+	_ modules.Module                   = &Module{}
+	_ modules.InitializableModule      = &Module{}
+	_ modules.StartableModule          = &Module{}
+	_ modules.StoppableModule          = &Module{}
+	_ modules.ModulesAwareModule       = &Module{}
+	_ modules.ParsingStatusAwareModule = &Module{}
+	_ modules.DatabaseAwareModule      = &Module{}
+	_ modules.NodeAwareModule          = &Module{}
 )
 
 // Module represents the telemetry module
 type Module struct {
-	cfg *Config
+	junoCfg config.Config
+	cfg     *Config
+	db      modules.HeightDb
+	node    node.Node
+	mods    []modules.Module
+	status  *types.ParsingStatus
+	server  *http.Server
 }
 
-// NewModule returns a new Module implementation
+// NewModule returns a new Module implementation.
+// The module configuration is parsed and validated when calling Init.
 func NewModule(cfg config.Config) *Module {
-	bz, err := cfg.GetBytes()
-	if err != nil {
-		panic(err)
+	return &Module{
+		junoCfg: cfg,
+		status:  types.NewParsingStatus(),
 	}
+}
 
-	telemetryCfg, err := ParseConfig(bz)
+// Name implements modules.Module
+func (m *Module) Name() string {
+	return ModuleName
+}
+
+// Init implements modules.InitializableModule
+func (m *Module) Init() error {
+	cfg, err := m.junoCfg.GetModuleConfig(ModuleName)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	return &Module{
-		cfg: telemetryCfg,
+	m.cfg = cfg.(*Config)
+	return nil
+}
+
+// SetModules implements modules.ModulesAwareModule
+func (m *Module) SetModules(mods []modules.Module) {
+	m.mods = mods
+}
+
+// SetDatabase implements modules.DatabaseAwareModule
+func (m *Module) SetDatabase(db modules.HeightDb) {
+	m.db = db
+}
+
+// SetNode implements modules.NodeAwareModule
+func (m *Module) SetNode(node node.Node) {
+	m.node = node
+}
+
+// SetParsingStatus implements modules.ParsingStatusAwareModule
+func (m *Module) SetParsingStatus(status *types.ParsingStatus) {
+	m.status = status
+}
+
+// Start implements modules.StartableModule
+func (m *Module) Start() error {
+	m.server = newServer(m.cfg, m.newRouter())
+
+	// Listen synchronously so that errors (eg. port already in use) are returned
+	listener, err := net.Listen("tcp", m.server.Addr)
+	if err != nil {
+		return fmt.Errorf("error while starting Prometheus server: %s", err)
 	}
+
+	go func() {
+		err := m.server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			log.Error().Str("module", ModuleName).Err(err).Msg("error while serving Prometheus server")
+		}
+	}()
+
+	return nil
 }
 
-// Name implements modules.Module
-func (m *Module) Name() string {
-	return ModuleName
+// newRouter returns a new router serving the Prometheus metrics
+func newRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.Handle("/metrics", promhttp.Handler())
+	return router
+}
+
+// newServer returns a new server using the given configuration and handler
+func newServer(cfg *Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         fmt.Sprintf(":%d", cfg.Port),
+		Handler:      handler,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
 }
 
-// RunAdditionalOperations implements modules.AdditionalOperationsModule
-func (m *Module) RunAdditionalOperations() error {
-	return RunAdditionalOperations(m.cfg)
+// Stop implements modules.StoppableModule
+func (m *Module) Stop() error {
+	if m.server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return m.server.Shutdown(ctx)
 }
@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"fmt"
+
+	"github.com/forbole/juno/v5/types/config"
+)
+
+const (
+	// ExporterOTLP exports the spans to an OpenTelemetry collector using the OTLP gRPC protocol
+	ExporterOTLP = "otlp"
+
+	// ExporterStdout writes the spans to the standard output
+	ExporterStdout = "stdout"
+
+	// ExporterFile writes the spans to a file
+	ExporterFile = "file"
+)
+
+var (
+	_ config.ModuleConfig = &Config{}
+)
+
+func init() {
+	config.RegisterModuleConfig(ModuleName, "tracing", func() config.ModuleConfig {
+		return DefaultConfig()
+	})
+}
+
+// Config represents the configuration of the tracing module
+type Config struct {
+	// ServiceName represents the name of the service that is attached to each span
+	ServiceName string `yaml:"service_name"`
+
+	// Exporter tells where the spans should be exported (either otlp, stdout or file)
+	Exporter string `yaml:"exporter"`
+
+	// Endpoint represents the address of the OpenTelemetry collector, used by the otlp exporter
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// Insecure tells whether the connection to the OpenTelemetry collector should not use TLS
+	Insecure bool `yaml:"insecure,omitempty"`
+
+	// FilePath represents the path of the file to which the spans are written, used by the file exporter
+	FilePath string `yaml:"file_path,omitempty"`
+
+	// SampleRatio represents the ratio of heights whose spans should be exported, between 0 and 1
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// NewConfig allows to build a new Config instance
+func NewConfig(serviceName, exporter, endpoint string, insecure bool, filePath string, sampleRatio float64) *Config {
+	return &Config{
+		ServiceName: serviceName,
+		Exporter:    exporter,
+		Endpoint:    endpoint,
+		Insecure:    insecure,
+		FilePath:    filePath,
+		SampleRatio: sampleRatio,
+	}
+}
+
+// DefaultConfig returns the default Config instance
+func DefaultConfig() *Config {
+	return NewConfig("juno", ExporterOTLP, "localhost:4317", false, "", 1)
+}
+
+// Validate implements config.ModuleConfig
+func (c *Config) Validate() error {
+	if c.ServiceName == "" {
+		return fmt.Errorf("service_name cannot be empty")
+	}
+
+	switch c.Exporter {
+	case ExporterOTLP:
+		if c.Endpoint == "" {
+			return fmt.Errorf("endpoint cannot be empty when using the %s exporter", ExporterOTLP)
+		}
+
+	case ExporterStdout:
+		break
+
+	case ExporterFile:
+		if c.FilePath == "" {
+			return fmt.Errorf("file_path cannot be empty when using the %s exporter", ExporterFile)
+		}
+
+	default:
+		return fmt.Errorf("invalid exporter: %s", c.Exporter)
+	}
+
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("sample_ratio must be between 0 and 1")
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package tracing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/modules/tracing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		cfg       *tracing.Config
+		shouldErr bool
+	}{
+		{
+			name:      "default config is valid",
+			cfg:       tracing.DefaultConfig(),
+			shouldErr: false,
+		},
+		{
+			name:      "stdout exporter is valid",
+			cfg:       tracing.NewConfig("juno", tracing.ExporterStdout, "", false, "", 0.5),
+			shouldErr: false,
+		},
+		{
+			name:      "file exporter without path returns error",
+			cfg:       tracing.NewConfig("juno", tracing.ExporterFile, "", false, "", 1),
+			shouldErr: true,
+		},
+		{
+			name:      "unknown exporter returns error",
+			cfg:       tracing.NewConfig("juno", "jaeger", "", false, "", 1),
+			shouldErr: true,
+		},
+		{
+			name:      "invalid sample ratio returns error",
+			cfg:       tracing.NewConfig("juno", tracing.ExporterStdout, "", false, "", 2),
+			shouldErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.shouldErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+const (
+	ModuleName = "tracing"
+)
+
+var (
+	_ modules.Module              = &Module{}
+	_ modules.InitializableModule = &Module{}
+	_ modules.StoppableModule     = &Module{}
+)
+
+// Module represents the tracing module, which sets up the OpenTelemetry tracer provider used to trace
+// the parsing of each height
+type Module struct {
+	junoCfg  config.Config
+	cfg      *Config
+	provider *sdktrace.TracerProvider
+	file     io.Closer
+}
+
+// NewModule returns a new Module instance.
+// The module configuration is parsed and validated when calling Init.
+func NewModule(cfg config.Config) *Module {
+	return &Module{
+		junoCfg: cfg,
+	}
+}
+
+// Name implements modules.Module
+func (m *Module) Name() string {
+	return ModuleName
+}
+
+// Init implements modules.InitializableModule
+func (m *Module) Init() error {
+	cfg, err := m.junoCfg.GetModuleConfig(ModuleName)
+	if err != nil {
+		return err
+	}
+
+	m.cfg = cfg.(*Config)
+
+	exporter, err := m.buildExporter()
+	if err != nil {
+		return fmt.Errorf("error while building %s exporter: %s", m.cfg.Exporter, err)
+	}
+
+	m.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", m.cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(m.cfg.SampleRatio))),
+	)
+
+	// Set the provider as the global one so that it is used by the parser
+	otel.SetTracerProvider(m.provider)
+	return nil
+}
+
+// buildExporter builds the spans exporter based on the module configuration
+func (m *Module) buildExporter() (sdktrace.SpanExporter, error) {
+	switch m.cfg.Exporter {
+	case ExporterOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(m.cfg.Endpoint)}
+		if m.cfg.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+
+		// The connection is established in background, so that Juno can start even if the collector is not reachable
+		return otlptracegrpc.New(context.Background(), options...)
+
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+
+	case ExporterFile:
+		file, err := os.OpenFile(m.cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, err
+		}
+		m.file = file
+
+		return stdouttrace.New(stdouttrace.WithWriter(file))
+
+	default:
+		return nil, fmt.Errorf("invalid exporter: %s", m.cfg.Exporter)
+	}
+}
+
+// Stop implements modules.StoppableModule
+func (m *Module) Stop() error {
+	if m.provider == nil {
+		return nil
+	}
+
+	// Export all the pending spans before shutting down
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := m.provider.Shutdown(ctx)
+	if err != nil {
+		return fmt.Errorf("error while shutting down tracer provider: %s", err)
+	}
+
+	if m.file != nil {
+		return m.file.Close()
+	}
+
+	return nil
+}
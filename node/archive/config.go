@@ -0,0 +1,62 @@
+package archive
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// FormatDirectory represents an archive made of a directory containing one sub-directory for each height
+	FormatDirectory = "directory"
+
+	// FormatStream represents an archive made of a single (optionally gzip compressed) JSON lines file
+	FormatStream = "stream"
+
+	// EncodingJSON represents an archive whose data is encoded using the Tendermint JSON codec
+	EncodingJSON = "json"
+
+	// EncodingProtobuf represents an archive whose data is encoded as length-delimited Protobuf messages.
+	// This encoding is not supported yet.
+	EncodingProtobuf = "protobuf"
+)
+
+// Details represents the nodeconfig.Details implementation for an archive node
+type Details struct {
+	Path     string `yaml:"path"`
+	Format   string `yaml:"format"`
+	Encoding string `yaml:"encoding,omitempty"`
+}
+
+func NewDetails(path, format string) *Details {
+	return &Details{
+		Path:     path,
+		Format:   format,
+		Encoding: EncodingJSON,
+	}
+}
+
+func DefaultDetails() *Details {
+	return NewDetails("archive", FormatDirectory)
+}
+
+// Validate implements nodeconfig.Details
+func (d *Details) Validate() error {
+	if strings.TrimSpace(d.Path) == "" {
+		return fmt.Errorf("archive path cannot be empty")
+	}
+
+	switch d.Format {
+	case FormatDirectory, FormatStream:
+	default:
+		return fmt.Errorf("invalid archive format: %s", d.Format)
+	}
+
+	switch d.Encoding {
+	case "", EncodingJSON:
+		return nil
+	case EncodingProtobuf:
+		return fmt.Errorf("protobuf archives are not supported: please export the archive using the %s encoding", EncodingJSON)
+	default:
+		return fmt.Errorf("invalid archive encoding: %s", d.Encoding)
+	}
+}
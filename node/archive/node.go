@@ -0,0 +1,241 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	constypes "github.com/cometbft/cometbft/consensus/types"
+	tmjson "github.com/cometbft/cometbft/libs/json"
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/types"
+)
+
+var (
+	_ node.Node = &Node{}
+)
+
+// Node represents the node implementation that reads the data from an archive that has been
+// previously exported on disk. It does not require any connection to a running chain node.
+type Node struct {
+	reader reader
+
+	txIndexOnce sync.Once
+	txIndex     map[string]int64
+	txIndexErr  error
+}
+
+// NewNode returns a new Node instance reading the archive described by the given details
+func NewNode(cfg *Details) (*Node, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive config: %s", err)
+	}
+
+	reader, err := newReader(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		reader: reader,
+	}, nil
+}
+
+// read reads the data of the given kind at the given height, and decodes it into dest using the Tendermint JSON codec
+func (n *Node) read(height int64, kind string, dest interface{}) error {
+	bz, err := n.reader.Read(height, kind)
+	if err != nil {
+		return fmt.Errorf("error while reading %s at height %d: %s", kind, height, err)
+	}
+
+	err = tmjson.Unmarshal(bz, dest)
+	if err != nil {
+		return fmt.Errorf("error while decoding %s at height %d: %s", kind, height, err)
+	}
+
+	return nil
+}
+
+// Genesis implements node.Node
+func (n *Node) Genesis() (*tmctypes.ResultGenesis, error) {
+	var genesis tmctypes.ResultGenesis
+	err := n.read(0, KindGenesis, &genesis.Genesis)
+	if err != nil {
+		return nil, err
+	}
+	return &genesis, nil
+}
+
+// ConsensusState implements node.Node
+func (n *Node) ConsensusState() (*constypes.RoundStateSimple, error) {
+	return nil, fmt.Errorf("consensus state is not available inside an archive")
+}
+
+// LatestHeight implements node.Node
+func (n *Node) LatestHeight() (int64, error) {
+	heights := n.reader.Heights()
+	if len(heights) == 0 {
+		return 0, nil
+	}
+	return heights[len(heights)-1], nil
+}
+
+// ChainID implements node.Node
+func (n *Node) ChainID() (string, error) {
+	genesis, err := n.Genesis()
+	if err == nil {
+		return genesis.Genesis.ChainID, nil
+	}
+
+	// If the genesis is not archived, use the chain id of the first archived block
+	heights := n.reader.Heights()
+	if len(heights) == 0 {
+		return "", fmt.Errorf("archive is empty")
+	}
+
+	block, err := n.Block(heights[0])
+	if err != nil {
+		return "", err
+	}
+
+	return block.Block.ChainID, nil
+}
+
+// Validators implements node.Node
+func (n *Node) Validators(height int64) (*tmctypes.ResultValidators, error) {
+	var vals tmctypes.ResultValidators
+	err := n.read(height, KindValidators, &vals)
+	if err != nil {
+		return nil, err
+	}
+	return &vals, nil
+}
+
+// Block implements node.Node
+func (n *Node) Block(height int64) (*tmctypes.ResultBlock, error) {
+	var block tmctypes.ResultBlock
+	err := n.read(height, KindBlock, &block)
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// BlockResults implements node.Node
+func (n *Node) BlockResults(height int64) (*tmctypes.ResultBlockResults, error) {
+	var results tmctypes.ResultBlockResults
+	err := n.read(height, KindBlockResults, &results)
+	if err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+// readTxs reads all the transactions that are archived for the given height
+func (n *Node) readTxs(height int64) ([]*types.Transaction, error) {
+	bz, err := n.reader.Read(height, KindTxs)
+	if err == ErrNotFound {
+		// Blocks without transactions might not have any txs file
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while reading txs at height %d: %s", height, err)
+	}
+
+	var txs []*types.Transaction
+	err = json.Unmarshal(bz, &txs)
+	if err != nil {
+		return nil, fmt.Errorf("error while decoding txs at height %d: %s", height, err)
+	}
+
+	return txs, nil
+}
+
+// buildTxIndex builds the index that associates each transaction hash to the height of the block containing it
+func (n *Node) buildTxIndex() {
+	n.txIndex = make(map[string]int64)
+	for _, height := range n.reader.Heights() {
+		txs, err := n.readTxs(height)
+		if err != nil {
+			n.txIndexErr = err
+			return
+		}
+
+		for _, tx := range txs {
+			n.txIndex[strings.ToUpper(tx.TxHash)] = height
+		}
+	}
+}
+
+// Tx implements node.Node
+func (n *Node) Tx(hash string) (*types.Transaction, error) {
+	n.txIndexOnce.Do(n.buildTxIndex)
+	if n.txIndexErr != nil {
+		return nil, fmt.Errorf("error while indexing archived transactions: %s", n.txIndexErr)
+	}
+
+	height, ok := n.txIndex[strings.ToUpper(hash)]
+	if !ok {
+		return nil, fmt.Errorf("tx %s not found", hash)
+	}
+
+	txs, err := n.readTxs(height)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txs {
+		if strings.EqualFold(tx.TxHash, hash) {
+			return tx, nil
+		}
+	}
+
+	return nil, fmt.Errorf("tx %s not found", hash)
+}
+
+// Txs implements node.Node
+func (n *Node) Txs(block *tmctypes.ResultBlock) ([]*types.Transaction, error) {
+	txs, err := n.readTxs(block.Block.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(txs) != len(block.Block.Txs) {
+		return nil, fmt.Errorf("archive contains %d txs for block %d, expected %d",
+			len(txs), block.Block.Height, len(block.Block.Txs))
+	}
+
+	return txs, nil
+}
+
+// TxSearch implements node.Node
+func (n *Node) TxSearch(_ string, _ *int, _ *int, _ string) (*tmctypes.ResultTxSearch, error) {
+	return nil, fmt.Errorf("tx search is not supported by the archive node")
+}
+
+// SubscribeEvents implements node.Node
+func (n *Node) SubscribeEvents(_, _ string) (<-chan tmctypes.ResultEvent, context.CancelFunc, error) {
+	_, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	eventCh := make(<-chan tmctypes.ResultEvent)
+	return eventCh, cancel, nil
+}
+
+// SubscribeNewBlocks implements node.Node
+func (n *Node) SubscribeNewBlocks(subscriber string) (<-chan tmctypes.ResultEvent, context.CancelFunc, error) {
+	return n.SubscribeEvents(subscriber, "tm.event = 'NewBlock'")
+}
+
+// Stop implements node.Node
+func (n *Node) Stop() {
+	err := n.reader.Close()
+	if err != nil {
+		log.Error().Err(err).Msg("error while closing archive")
+	}
+}
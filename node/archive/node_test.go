@@ -0,0 +1,147 @@
+package archive_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+
+	tmjson "github.com/cometbft/cometbft/libs/json"
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/node/archive"
+)
+
+func buildBlock(t *testing.T, height int64) []byte {
+	bz, err := tmjson.Marshal(&tmctypes.ResultBlock{
+		Block: &tmtypes.Block{Header: tmtypes.Header{ChainID: "test-chain", Height: height}},
+	})
+	require.NoError(t, err)
+	return bz
+}
+
+func TestNode_Directory(t *testing.T) {
+	dir := t.TempDir()
+	for _, height := range []int64{10, 2} {
+		heightDir := path.Join(dir, strconv.FormatInt(height, 10))
+		require.NoError(t, os.MkdirAll(heightDir, 0700))
+		require.NoError(t, os.WriteFile(path.Join(heightDir, "block.json"), buildBlock(t, height), 0600))
+	}
+
+	// Write the txs of height 2 as a compressed file
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	_, err := gzipWriter.Write([]byte(`[]`))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, os.WriteFile(path.Join(dir, "2", "txs.json.gz"), buf.Bytes(), 0600))
+
+	node, err := archive.NewNode(archive.NewDetails(dir, archive.FormatDirectory))
+	require.NoError(t, err)
+
+	latestHeight, err := node.LatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(10), latestHeight)
+
+	block, err := node.Block(2)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), block.Block.Height)
+
+	txs, err := node.Txs(block)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+
+	_, err = node.Validators(2)
+	require.Error(t, err)
+}
+
+func TestNode_Stream(t *testing.T) {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gzipWriter)
+	for _, height := range []int64{3, 1, 2} {
+		require.NoError(t, encoder.Encode(archive.Entry{
+			Height: height,
+			Block:  buildBlock(t, height),
+		}))
+	}
+	require.NoError(t, gzipWriter.Close())
+
+	filePath := path.Join(t.TempDir(), "archive.jsonl.gz")
+	require.NoError(t, os.WriteFile(filePath, buf.Bytes(), 0600))
+
+	node, err := archive.NewNode(archive.NewDetails(filePath, archive.FormatStream))
+	require.NoError(t, err)
+
+	latestHeight, err := node.LatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), latestHeight)
+
+	chainID, err := node.ChainID()
+	require.NoError(t, err)
+	require.Equal(t, "test-chain", chainID)
+
+	block, err := node.Block(2)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), block.Block.Height)
+
+	txs, err := node.Txs(block)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+
+	_, err = node.Block(4)
+	require.Error(t, err)
+}
+
+func TestNode_StreamOutOfOrderReads(t *testing.T) {
+	for _, compressed := range []bool{false, true} {
+		var buf bytes.Buffer
+		writer := io.Writer(&buf)
+
+		gzipWriter := gzip.NewWriter(&buf)
+		if compressed {
+			writer = gzipWriter
+		}
+
+		// Store more entries than the ones that can be cached
+		encoder := json.NewEncoder(writer)
+		for height := int64(1); height <= 250; height++ {
+			require.NoError(t, encoder.Encode(archive.Entry{
+				Height: height,
+				Block:  buildBlock(t, height),
+			}))
+		}
+		if compressed {
+			require.NoError(t, gzipWriter.Close())
+		}
+
+		filePath := path.Join(t.TempDir(), "archive.jsonl")
+		require.NoError(t, os.WriteFile(filePath, buf.Bytes(), 0600))
+
+		node, err := archive.NewNode(archive.NewDetails(filePath, archive.FormatStream))
+		require.NoError(t, err)
+
+		for _, height := range []int64{250, 1, 200, 199, 2, 250} {
+			block, err := node.Block(height)
+			require.NoError(t, err)
+			require.Equal(t, height, block.Block.Height)
+		}
+
+		node.Stop()
+	}
+}
+
+func TestNewNode_UnsupportedEncoding(t *testing.T) {
+	details := archive.NewDetails(t.TempDir(), archive.FormatDirectory)
+	details.Encoding = archive.EncodingProtobuf
+
+	_, err := archive.NewNode(details)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "protobuf archives are not supported")
+}
@@ -0,0 +1,516 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+const (
+	KindGenesis      = "genesis"
+	KindBlock        = "block"
+	KindBlockResults = "block_results"
+	KindValidators   = "validators"
+	KindTxs          = "txs"
+)
+
+var (
+	// ErrNotFound is returned when the requested data is not present inside the archive
+	ErrNotFound = errors.New("not found inside archive")
+)
+
+// Entry contains all the data that is stored inside a stream archive for a single height.
+// The genesis, if present, is stored inside the entry having height 0.
+type Entry struct {
+	Height       int64           `json:"height"`
+	Genesis      json.RawMessage `json:"genesis,omitempty"`
+	Block        json.RawMessage `json:"block,omitempty"`
+	BlockResults json.RawMessage `json:"block_results,omitempty"`
+	Validators   json.RawMessage `json:"validators,omitempty"`
+	Txs          json.RawMessage `json:"txs,omitempty"`
+}
+
+// get returns the data of the given kind contained inside the entry
+func (e *Entry) get(kind string) json.RawMessage {
+	switch kind {
+	case KindGenesis:
+		return e.Genesis
+	case KindBlock:
+		return e.Block
+	case KindBlockResults:
+		return e.BlockResults
+	case KindValidators:
+		return e.Validators
+	case KindTxs:
+		return e.Txs
+	default:
+		return nil
+	}
+}
+
+// reader represents a generic archive reader
+type reader interface {
+	// Heights returns all the heights stored inside the archive, sorted in ascending order
+	Heights() []int64
+
+	// Read returns the raw JSON data of the given kind stored for the given height.
+	// The genesis must be read using height 0.
+	// If no data is found, ErrNotFound is returned.
+	Read(height int64, kind string) ([]byte, error)
+
+	// Close closes the reader
+	Close() error
+}
+
+// newReader returns the reader to be used for the archive described by the given details
+func newReader(cfg *Details) (reader, error) {
+	switch cfg.Format {
+	case FormatDirectory:
+		return newDirectoryReader(cfg.Path)
+	case FormatStream:
+		return newStreamReader(cfg.Path)
+	default:
+		return nil, fmt.Errorf("invalid archive format: %s", cfg.Format)
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+var (
+	_ reader = &Directory{}
+)
+
+// Directory reads and writes the data from a directory that has the following layout:
+//
+//	<path>/genesis.json
+//	<path>/<height>/block.json
+//	<path>/<height>/block_results.json
+//	<path>/<height>/validators.json
+//	<path>/<height>/txs.json
+//
+// Each file can also be gzip compressed, in which case it must have the .json.gz extension.
+type Directory struct {
+	path string
+
+	mu      sync.RWMutex
+	heights []int64
+}
+
+// OpenDirectory opens the archive directory present at the given path, creating it if it does not exist yet
+func OpenDirectory(dirPath string) (*Directory, error) {
+	err := os.MkdirAll(dirPath, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating archive directory: %s", err)
+	}
+
+	return newDirectoryReader(dirPath)
+}
+
+func newDirectoryReader(dirPath string) (*Directory, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading archive directory: %s", err)
+	}
+
+	var heights []int64
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		height, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil || height <= 0 {
+			// Skip all the directories that do not represent a height
+			continue
+		}
+		heights = append(heights, height)
+	}
+
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	return &Directory{
+		path:    dirPath,
+		heights: heights,
+	}, nil
+}
+
+// getFilePath returns the path of the file containing the data of the given kind for the given height
+func (d *Directory) getFilePath(height int64, kind string) string {
+	if kind == KindGenesis {
+		return path.Join(d.path, kind+".json")
+	}
+	return path.Join(d.path, strconv.FormatInt(height, 10), kind+".json")
+}
+
+// Heights implements reader.
+// The returned slice must not be modified, as it is shared with the Directory itself.
+func (d *Directory) Heights() []int64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.heights
+}
+
+// Read implements reader
+func (d *Directory) Read(height int64, kind string) ([]byte, error) {
+	filePath := d.getFilePath(height, kind)
+
+	bz, err := os.ReadFile(filePath)
+	if err == nil {
+		return bz, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath + ".gz")
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading %s: %s", filePath+".gz", err)
+	}
+	defer gzipReader.Close()
+
+	return io.ReadAll(gzipReader)
+}
+
+// Write stores the given raw JSON data of the given kind for the given height.
+// The genesis must be written using height 0.
+func (d *Directory) Write(height int64, kind string, bz []byte) error {
+	filePath := d.getFilePath(height, kind)
+
+	err := os.MkdirAll(path.Dir(filePath), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that concurrent readers never see partial data
+	tmpFile, err := os.CreateTemp(path.Dir(filePath), kind+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(bz)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmpFile.Name(), filePath)
+	if err != nil {
+		return err
+	}
+
+	// Only heights having a block are considered to be stored
+	if kind == KindBlock {
+		d.addHeight(height)
+	}
+
+	return nil
+}
+
+// addHeight adds the given height to the list of the stored ones, if not present yet
+func (d *Directory) addHeight(height int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	index := sort.Search(len(d.heights), func(i int) bool { return d.heights[i] >= height })
+	if index < len(d.heights) && d.heights[index] == height {
+		return
+	}
+
+	heights := make([]int64, 0, len(d.heights)+1)
+	heights = append(heights, d.heights[:index]...)
+	heights = append(heights, height)
+	d.heights = append(heights, d.heights[index:]...)
+}
+
+// Close implements reader
+func (d *Directory) Close() error {
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+var (
+	_ reader = &streamReader{}
+)
+
+// streamCacheSize represents the max number of decoded entries that are kept in memory by a streamReader
+const streamCacheSize = 100
+
+// streamReader reads the data from a single file containing one JSON-encoded Entry per line.
+// The file can be gzip compressed, in which case it is decompressed transparently.
+//
+// Only the offset of each entry is kept in memory, along with a bounded cache of the most recently decoded entries.
+// Uncompressed streams are read at the offset of the requested entry, while compressed streams are decoded
+// sequentially, starting again from the beginning only when an entry that has already been passed is requested.
+type streamReader struct {
+	filePath   string
+	compressed bool
+
+	heights []int64
+	offsets map[int64]int64
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	cursor *streamCursor
+	cache  map[int64]*Entry
+	cacheQ []int64
+}
+
+// streamCursor allows to sequentially decode the entries of a stream
+type streamCursor struct {
+	file    *os.File
+	closer  io.Closer
+	decoder *json.Decoder
+}
+
+// openStreamCursor opens the stream present at the given path, returning a cursor positioned at its beginning
+func openStreamCursor(filePath string) (cursor *streamCursor, compressed bool, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, false, fmt.Errorf("error while opening archive stream: %s", err)
+	}
+
+	bufReader := bufio.NewReader(file)
+	source := io.Reader(bufReader)
+	var closer io.Closer
+
+	// Check the gzip magic number to see whether the stream is compressed or not
+	magic, err := bufReader.Peek(2)
+	if err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gzipReader, err := gzip.NewReader(bufReader)
+		if err != nil {
+			file.Close()
+			return nil, false, fmt.Errorf("error while reading archive stream: %s", err)
+		}
+		source = gzipReader
+		closer = gzipReader
+		compressed = true
+	}
+
+	return &streamCursor{
+		file:    file,
+		closer:  closer,
+		decoder: json.NewDecoder(source),
+	}, compressed, nil
+}
+
+// Offset returns the offset inside the decompressed stream at which the next entry starts
+func (c *streamCursor) Offset() int64 {
+	return c.decoder.InputOffset()
+}
+
+// Next decodes the next entry of the stream into dest, returning io.EOF when the stream has ended
+func (c *streamCursor) Next(dest interface{}) error {
+	return c.decoder.Decode(dest)
+}
+
+// Close closes the cursor
+func (c *streamCursor) Close() error {
+	if c.closer != nil {
+		c.closer.Close()
+	}
+	return c.file.Close()
+}
+
+func newStreamReader(filePath string) (*streamReader, error) {
+	cursor, compressed, err := openStreamCursor(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	// Index the offset of each entry, without keeping the entries themselves in memory
+	offsets := make(map[int64]int64)
+	for {
+		offset := cursor.Offset()
+
+		var entry struct {
+			Height int64 `json:"height"`
+		}
+		err = cursor.Next(&entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error while decoding archive stream entry: %s", err)
+		}
+
+		offsets[entry.Height] = offset
+	}
+
+	var heights []int64
+	for height := range offsets {
+		if height > 0 {
+			heights = append(heights, height)
+		}
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	r := &streamReader{
+		filePath:   filePath,
+		compressed: compressed,
+		heights:    heights,
+		offsets:    offsets,
+		cache:      make(map[int64]*Entry),
+	}
+
+	if !compressed {
+		r.file, err = os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("error while opening archive stream: %s", err)
+		}
+
+		info, err := r.file.Stat()
+		if err != nil {
+			r.file.Close()
+			return nil, fmt.Errorf("error while reading archive stream: %s", err)
+		}
+		r.size = info.Size()
+	}
+
+	return r, nil
+}
+
+// Heights implements reader
+func (r *streamReader) Heights() []int64 {
+	return r.heights
+}
+
+// Read implements reader
+func (r *streamReader) Read(height int64, kind string) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, err := r.getEntry(height)
+	if err != nil {
+		return nil, err
+	}
+
+	bz := entry.get(kind)
+	if len(bz) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return bz, nil
+}
+
+// getEntry returns the entry having the given height, reading it from the cache if possible
+func (r *streamReader) getEntry(height int64) (*Entry, error) {
+	if entry, ok := r.cache[height]; ok {
+		return entry, nil
+	}
+
+	offset, ok := r.offsets[height]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	if r.compressed {
+		return r.readSequentially(height, offset)
+	}
+
+	var entry Entry
+	err := json.NewDecoder(io.NewSectionReader(r.file, offset, r.size-offset)).Decode(&entry)
+	if err != nil {
+		return nil, fmt.Errorf("error while decoding archive stream entry: %s", err)
+	}
+
+	r.cacheEntry(&entry)
+	return &entry, nil
+}
+
+// readSequentially decodes the entries of a compressed stream until the one at the given offset is reached,
+// caching all the decoded entries so that the ones being requested next are likely to be found inside the cache
+func (r *streamReader) readSequentially(height int64, offset int64) (*Entry, error) {
+	if r.cursor == nil || r.cursor.Offset() > offset {
+		if r.cursor != nil {
+			r.cursor.Close()
+		}
+
+		cursor, _, err := openStreamCursor(r.filePath)
+		if err != nil {
+			return nil, err
+		}
+		r.cursor = cursor
+	}
+
+	for {
+		// Only the entries that have been indexed are relevant, since duplicated heights are overridden
+		entryOffset := r.cursor.Offset()
+
+		var entry Entry
+		err := r.cursor.Next(&entry)
+		if err == io.EOF {
+			return nil, ErrNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error while decoding archive stream entry: %s", err)
+		}
+
+		if r.offsets[entry.Height] != entryOffset {
+			continue
+		}
+
+		r.cacheEntry(&entry)
+		if entry.Height == height {
+			return &entry, nil
+		}
+	}
+}
+
+// cacheEntry adds the given entry to the cache, evicting the oldest one if the cache is full
+func (r *streamReader) cacheEntry(entry *Entry) {
+	if _, ok := r.cache[entry.Height]; ok {
+		return
+	}
+
+	if len(r.cacheQ) >= streamCacheSize {
+		delete(r.cache, r.cacheQ[0])
+		r.cacheQ = r.cacheQ[1:]
+	}
+
+	r.cache[entry.Height] = entry
+	r.cacheQ = append(r.cacheQ, entry.Height)
+}
+
+// Close implements reader
+func (r *streamReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cache = nil
+	r.cacheQ = nil
+
+	if r.cursor != nil {
+		r.cursor.Close()
+		r.cursor = nil
+	}
+
+	if r.file != nil {
+		return r.file.Close()
+	}
+
+	return nil
+}
@@ -6,17 +6,44 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/node/archive"
+	"github.com/forbole/juno/v5/node/cache"
 	nodeconfig "github.com/forbole/juno/v5/node/config"
 	"github.com/forbole/juno/v5/node/local"
+	"github.com/forbole/juno/v5/node/metrics"
 	"github.com/forbole/juno/v5/node/remote"
 )
 
 func BuildNode(cfg nodeconfig.Config, txConfig client.TxConfig, codec codec.Codec) (node.Node, error) {
+	if cfg.Type == nodeconfig.TypeNone {
+		return nil, nil
+	}
+
+	var built node.Node
+	var err error
+	if cfg.Cache != nil {
+		built, err = cache.NewNode(cfg.Cache, func() (node.Node, error) {
+			return buildNode(cfg, txConfig, codec)
+		})
+	} else {
+		built, err = buildNode(cfg, txConfig, codec)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Track the time spent calling each node method
+	return metrics.NewNode(built), nil
+}
+
+func buildNode(cfg nodeconfig.Config, txConfig client.TxConfig, codec codec.Codec) (node.Node, error) {
 	switch cfg.Type {
 	case nodeconfig.TypeRemote:
 		return remote.NewNode(cfg.Details.(*remote.Details))
 	case nodeconfig.TypeLocal:
 		return local.NewNode(cfg.Details.(*local.Details), txConfig, codec)
+	case nodeconfig.TypeArchive:
+		return archive.NewNode(cfg.Details.(*archive.Details))
 	case nodeconfig.TypeNone:
 		return nil, nil
 
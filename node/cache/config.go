@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ModeRecord serves the cached responses when available, and stores inside the cache
+	// all the responses that are fetched from the underlying node
+	ModeRecord = "record"
+
+	// ModeReplay serves only the cached responses, without ever contacting the underlying node
+	ModeReplay = "replay"
+)
+
+// Config contains the configuration of the node responses cache
+type Config struct {
+	Path string `yaml:"path"`
+	Mode string `yaml:"mode"`
+}
+
+// NewConfig allows to build a new Config instance
+func NewConfig(path, mode string) *Config {
+	return &Config{
+		Path: path,
+		Mode: mode,
+	}
+}
+
+// Validate checks whether the given configuration is valid
+func (c *Config) Validate() error {
+	if strings.TrimSpace(c.Path) == "" {
+		return fmt.Errorf("cache path cannot be empty")
+	}
+
+	switch c.Mode {
+	case ModeRecord, ModeReplay:
+		return nil
+	default:
+		return fmt.Errorf("invalid cache mode: %s", c.Mode)
+	}
+}
@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tmjson "github.com/cometbft/cometbft/libs/json"
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/node/archive"
+	"github.com/forbole/juno/v5/types"
+)
+
+var (
+	_ node.Node = &Node{}
+)
+
+// Node represents a node.Node decorator that records the responses of the wrapped node inside a local cache.
+// The cache uses the same layout of a directory archive, so that it can later be replayed without any
+// network access using either the ModeReplay mode or an archive node.
+type Node struct {
+	node.Node
+
+	dir *archive.Directory
+}
+
+// NewNode returns the node.Node that should be used based on the given configuration.
+// When using the ModeRecord mode, the returned node wraps the one built using buildNode.
+// When using the ModeReplay mode, buildNode is never called and the responses are read from the cache only.
+func NewNode(cfg *Config, buildNode func() (node.Node, error)) (node.Node, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Mode == ModeReplay {
+		return archive.NewNode(archive.NewDetails(cfg.Path, archive.FormatDirectory))
+	}
+
+	wrapped, err := buildNode()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := archive.OpenDirectory(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Node: wrapped,
+		dir:  dir,
+	}, nil
+}
+
+// get reads the cached data of the given kind at the given height and decodes it into dest using the
+// given unmarshaler. It returns true if the data has been found inside the cache, false otherwise.
+func (n *Node) get(height int64, kind string, dest interface{}, unmarshal func([]byte, interface{}) error) (bool, error) {
+	bz, err := n.dir.Read(height, kind)
+	if err == archive.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error while reading cached %s at height %d: %s", kind, height, err)
+	}
+
+	err = unmarshal(bz, dest)
+	if err != nil {
+		return false, fmt.Errorf("error while decoding cached %s at height %d: %s", kind, height, err)
+	}
+
+	return true, nil
+}
+
+// set encodes the given value using the given marshaler and stores it inside the cache
+func (n *Node) set(height int64, kind string, value interface{}, marshal func(interface{}) ([]byte, error)) error {
+	bz, err := marshal(value)
+	if err != nil {
+		return fmt.Errorf("error while encoding %s at height %d: %s", kind, height, err)
+	}
+
+	err = n.dir.Write(height, kind, bz)
+	if err != nil {
+		return fmt.Errorf("error while caching %s at height %d: %s", kind, height, err)
+	}
+
+	return nil
+}
+
+// Genesis implements node.Node
+func (n *Node) Genesis() (*tmctypes.ResultGenesis, error) {
+	var genesis *tmtypes.GenesisDoc
+	found, err := n.get(0, archive.KindGenesis, &genesis, tmjson.Unmarshal)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return &tmctypes.ResultGenesis{Genesis: genesis}, nil
+	}
+
+	res, err := n.Node.Genesis()
+	if err != nil {
+		return nil, err
+	}
+
+	return res, n.set(0, archive.KindGenesis, res.Genesis, tmjson.Marshal)
+}
+
+// Validators implements node.Node
+func (n *Node) Validators(height int64) (*tmctypes.ResultValidators, error) {
+	var vals *tmctypes.ResultValidators
+	found, err := n.get(height, archive.KindValidators, &vals, tmjson.Unmarshal)
+	if err != nil || found {
+		return vals, err
+	}
+
+	vals, err = n.Node.Validators(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return vals, n.set(height, archive.KindValidators, vals, tmjson.Marshal)
+}
+
+// Block implements node.Node
+func (n *Node) Block(height int64) (*tmctypes.ResultBlock, error) {
+	var block *tmctypes.ResultBlock
+	found, err := n.get(height, archive.KindBlock, &block, tmjson.Unmarshal)
+	if err != nil || found {
+		return block, err
+	}
+
+	block, err = n.Node.Block(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return block, n.set(height, archive.KindBlock, block, tmjson.Marshal)
+}
+
+// BlockResults implements node.Node
+func (n *Node) BlockResults(height int64) (*tmctypes.ResultBlockResults, error) {
+	var results *tmctypes.ResultBlockResults
+	found, err := n.get(height, archive.KindBlockResults, &results, tmjson.Unmarshal)
+	if err != nil || found {
+		return results, err
+	}
+
+	results, err = n.Node.BlockResults(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, n.set(height, archive.KindBlockResults, results, tmjson.Marshal)
+}
+
+// Txs implements node.Node
+func (n *Node) Txs(block *tmctypes.ResultBlock) ([]*types.Transaction, error) {
+	height := block.Block.Height
+
+	var txs []*types.Transaction
+	found, err := n.get(height, archive.KindTxs, &txs, json.Unmarshal)
+	if err != nil || found {
+		return txs, err
+	}
+
+	txs, err = n.Node.Txs(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return txs, n.set(height, archive.KindTxs, txs, json.Marshal)
+}
@@ -0,0 +1,61 @@
+package cache_test
+
+import (
+	"testing"
+
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/node/cache"
+)
+
+// mockNode represents a node.Node implementation that counts the number of times a block has been requested
+type mockNode struct {
+	node.Node
+
+	blockCalls int
+}
+
+func (n *mockNode) Block(height int64) (*tmctypes.ResultBlock, error) {
+	n.blockCalls++
+	return &tmctypes.ResultBlock{
+		Block: &tmtypes.Block{Header: tmtypes.Header{ChainID: "test-chain", Height: height}},
+	}, nil
+}
+
+func TestNode_RecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	mock := &mockNode{}
+
+	recorder, err := cache.NewNode(cache.NewConfig(dir, cache.ModeRecord), func() (node.Node, error) {
+		return mock, nil
+	})
+	require.NoError(t, err)
+
+	// The first call should hit the wrapped node, the second one should be served by the cache
+	for i := 0; i < 2; i++ {
+		block, err := recorder.Block(5)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), block.Block.Height)
+	}
+	require.Equal(t, 1, mock.blockCalls)
+
+	replayer, err := cache.NewNode(cache.NewConfig(dir, cache.ModeReplay), func() (node.Node, error) {
+		t.Fatal("the wrapped node should not be built in replay mode")
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	block, err := replayer.Block(5)
+	require.NoError(t, err)
+	require.Equal(t, "test-chain", block.Block.ChainID)
+
+	latestHeight, err := replayer.LatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), latestHeight)
+
+	_, err = replayer.Block(6)
+	require.Error(t, err)
+}
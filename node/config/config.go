@@ -3,19 +3,23 @@ package config
 import (
 	"gopkg.in/yaml.v3"
 
+	"github.com/forbole/juno/v5/node/archive"
+	"github.com/forbole/juno/v5/node/cache"
 	"github.com/forbole/juno/v5/node/local"
 	"github.com/forbole/juno/v5/node/remote"
 )
 
 const (
-	TypeRemote = "remote"
-	TypeLocal  = "local"
-	TypeNone   = "none"
+	TypeRemote  = "remote"
+	TypeLocal   = "local"
+	TypeArchive = "archive"
+	TypeNone    = "none"
 )
 
 type Config struct {
-	Type    string  `yaml:"type"`
-	Details Details `yaml:"-"`
+	Type    string        `yaml:"type"`
+	Details Details       `yaml:"-"`
+	Cache   *cache.Config `yaml:"cache,omitempty"`
 }
 
 func NewConfig(nodeType string, details Details) Config {
@@ -46,6 +50,8 @@ func (s *Config) UnmarshalYAML(n *yaml.Node) error {
 		s.Details = new(remote.Details)
 	case TypeLocal:
 		s.Details = new(local.Details)
+	case TypeArchive:
+		s.Details = new(archive.Details)
 	default:
 		panic("unknown node type")
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 
+	"github.com/forbole/juno/v5/node/archive"
 	nodeconfig "github.com/forbole/juno/v5/node/config"
 	"github.com/forbole/juno/v5/node/local"
 	"github.com/forbole/juno/v5/node/remote"
@@ -43,6 +44,18 @@ config:
 	err = yaml.Unmarshal([]byte(localData), &config)
 	require.NoError(t, err)
 	require.IsType(t, &local.Details{}, config.Details)
+
+	var archiveData = `
+type: "archive"
+config:
+  path: /home/user/archive
+  format: stream
+`
+
+	err = yaml.Unmarshal([]byte(archiveData), &config)
+	require.NoError(t, err)
+	require.IsType(t, &archive.Details{}, config.Details)
+	require.Equal(t, archive.FormatStream, config.Details.(*archive.Details).Format)
 }
 
 func TestConfig_MarshalYAML(t *testing.T) {
@@ -10,6 +10,23 @@ import (
 // Details represents the nodeconfig.Details implementation for a local node
 type Details struct {
 	Home string `yaml:"home"`
+
+	// ReadOnly tells whether the node data should be accessed in read-only mode.
+	// When enabled, the databases are opened without write access, and no consensus related service is started.
+	ReadOnly bool `yaml:"read_only,omitempty"`
+
+	// DBBackend represents the backend of the node databases (eg. "goleveldb" or "rocksdb").
+	// If empty, the db_backend value set inside the node config.toml file is used instead.
+	DBBackend string `yaml:"db_backend,omitempty"`
+
+	// ApplicationDBDir represents the directory containing the application.db database.
+	// If empty, the data directory inside the home folder is used instead.
+	ApplicationDBDir string `yaml:"application_db_dir,omitempty"`
+
+	// BlockStoreDBDir represents the directory containing the blockstore.db database, along with
+	// the other CometBFT databases (eg. state.db and tx_index.db).
+	// If empty, the db_dir value set inside the node config.toml file is used instead.
+	BlockStoreDBDir string `yaml:"blockstore_db_dir,omitempty"`
 }
 
 func NewDetails(home string) *Details {
@@ -26,6 +43,14 @@ func DefaultDetails() *Details {
 	return NewDetails(path.Join(home, ".simd"))
 }
 
+// GetApplicationDBDir returns the directory containing the application.db database
+func (d *Details) GetApplicationDBDir() string {
+	if strings.TrimSpace(d.ApplicationDBDir) == "" {
+		return path.Join(d.Home, "data")
+	}
+	return d.ApplicationDBDir
+}
+
 // Validate implements nodeconfig.Details
 func (d *Details) Validate() error {
 	if strings.TrimSpace(d.Home) == "" {
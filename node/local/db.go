@@ -0,0 +1,89 @@
+package local
+
+import (
+	"fmt"
+	"sync"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cfg "github.com/cometbft/cometbft/config"
+	tmnode "github.com/cometbft/cometbft/node"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// DBOpener represents a function that allows to open the database having the given name inside the given directory.
+// If readOnly is true, the returned database must not allow any write.
+type DBOpener func(name, dir string, readOnly bool) (dbm.DB, error)
+
+var (
+	dbOpenersMu sync.RWMutex
+	dbOpeners   = map[string]DBOpener{}
+
+	// readOnlyOpeners contains the functions used to open in read-only mode the databases having the backends
+	// that are only built with the corresponding build tag (eg. "rocksdb")
+	readOnlyOpeners = map[string]func(name, dir string) (dbm.DB, error){}
+)
+
+// RegisterDBBackend registers the given opener to be used for the databases having the given backend.
+// This allows to support backends that are not built inside the cometbft-db version used by Juno (eg. "pebbledb"),
+// or to override the way the built-in ones are opened.
+// NOTE. This must be called before building the local node or source.
+func RegisterDBBackend(backend string, opener DBOpener) {
+	dbOpenersMu.Lock()
+	defer dbOpenersMu.Unlock()
+	dbOpeners[backend] = opener
+}
+
+// openDB opens the database having the given name inside the given directory using the provided backend.
+// If readOnly is true, the database files are never created nor modified. If a goleveldb database is
+// currently locked by a running node, a snapshot of it is opened instead.
+func openDB(name string, backend string, dir string, readOnly bool) (dbm.DB, error) {
+	dbOpenersMu.RLock()
+	opener, found := dbOpeners[backend]
+	dbOpenersMu.RUnlock()
+
+	if found {
+		return opener(name, dir, readOnly)
+	}
+
+	if !readOnly {
+		return dbm.NewDB(name, dbm.BackendType(backend), dir)
+	}
+
+	switch dbm.BackendType(backend) {
+	case dbm.GoLevelDBBackend:
+		db, err := dbm.NewGoLevelDBWithOpts(name, dir, &opt.Options{
+			ReadOnly:       true,
+			ErrorIfMissing: true,
+		})
+		if err != nil && isLockedErr(err) {
+			return openGoLevelDBSnapshot(name, dir)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error while opening %s database in read-only mode: %s", name, err)
+		}
+		return db, nil
+
+	default:
+		readOnlyOpener, found := readOnlyOpeners[backend]
+		if !found {
+			return nil, fmt.Errorf("read-only mode is not supported for %s database backend", backend)
+		}
+
+		db, err := readOnlyOpener(name, dir)
+		if err != nil {
+			return nil, fmt.Errorf("error while opening %s database in read-only mode: %s", name, err)
+		}
+		return db, nil
+	}
+}
+
+// openReadOnlyDB opens the database having the given id (eg. "blockstore" or "state") inside the data
+// directory specified by the given config, without allowing any write to it.
+func openReadOnlyDB(id string, config *cfg.Config) (dbm.DB, error) {
+	return openDB(id, config.DBBackend, config.DBDir(), true)
+}
+
+// nodeDBProvider is the tmnode.DBProvider that opens the node databases using the registered backends
+func nodeDBProvider(ctx *tmnode.DBContext) (dbm.DB, error) {
+	return openDB(ctx.ID, ctx.Config.DBBackend, ctx.Config.DBDir(), false)
+}
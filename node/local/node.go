@@ -5,7 +5,6 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
-	"path"
 	"sort"
 	"time"
 
@@ -30,7 +29,7 @@ import (
 	tmtypes "github.com/cometbft/cometbft/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/types/tx"
-	"github.com/spf13/viper"
+	zerolog "github.com/rs/zerolog/log"
 
 	"github.com/forbole/juno/v5/node"
 	"github.com/forbole/juno/v5/types"
@@ -69,20 +68,25 @@ type Node struct {
 	consensusState *cs.State
 	txIndexer      txindex.TxIndexer
 	blockIndexer   indexer.BlockIndexer
+
+	// dbs contains the databases opened in read-only mode, which are closed when stopping the node
+	dbs []dbm.DB
 }
 
 // NewNode returns a new Node instance
 func NewNode(config *Details, txConfig client.TxConfig, codec codec.Codec) (*Node, error) {
 	// Load the config
-	viper.SetConfigFile(path.Join(config.Home, "config", "config.yaml"))
-	tmCfg, err := ParseConfig()
+	tmCfg, err := loadConfig(config)
 	if err != nil {
 		return nil, err
 	}
-	tmCfg.SetRoot(config.Home)
+
+	if config.ReadOnly {
+		return newReadOnlyNode(tmCfg, txConfig, codec)
+	}
 
 	// Build the local node
-	dbProvider := tmnode.DefaultDBProvider
+	dbProvider := nodeDBProvider
 	genesisDocProvider := tmnode.DefaultGenesisDocProviderFunc(tmCfg)
 	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "explorer")
 	clientCreator := proxy.DefaultClientCreator(tmCfg.ProxyApp, tmCfg.ABCI, tmCfg.DBDir())
@@ -172,6 +176,85 @@ func NewNode(config *Details, txConfig client.TxConfig, codec codec.Codec) (*Nod
 	}, nil
 }
 
+// newReadOnlyNode returns a new Node instance that only reads the data stored inside the block store,
+// the state store and the tx indexer of the node, without ever writing to them.
+// Contrary to NewNode, it does not start any event bus, indexer service or consensus state, and it never
+// touches the private validator files.
+func newReadOnlyNode(tmCfg *cfg.Config, txConfig client.TxConfig, codec codec.Codec) (_ *Node, err error) {
+	var dbs []dbm.DB
+	defer func() {
+		// Close the databases already opened (deleting their snapshots) if the node cannot be built
+		if err != nil {
+			closeDBs(dbs)
+		}
+	}()
+
+	blockStoreDB, err := openReadOnlyDB("blockstore", tmCfg)
+	if err != nil {
+		return nil, err
+	}
+	dbs = append(dbs, blockStoreDB)
+
+	stateDB, err := openReadOnlyDB("state", tmCfg)
+	if err != nil {
+		return nil, err
+	}
+	dbs = append(dbs, stateDB)
+
+	genDoc, err := tmnode.DefaultGenesisDocProviderFunc(tmCfg)()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		txIndexer    txindex.TxIndexer
+		blockIndexer indexer.BlockIndexer
+	)
+
+	switch tmCfg.TxIndex.Indexer {
+	case "kv":
+		store, err := openReadOnlyDB("tx_index", tmCfg)
+		if err != nil {
+			return nil, err
+		}
+		dbs = append(dbs, store)
+
+		txIndexer = kv.NewTxIndex(store)
+		blockIndexer = blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+	default:
+		txIndexer = &null.TxIndex{}
+		blockIndexer = &blockidxnull.BlockerIndexer{}
+	}
+
+	return &Node{
+		ctx:      context.Background(),
+		codec:    codec,
+		txConfig: txConfig,
+
+		tmCfg:      tmCfg,
+		genesisDoc: genDoc,
+
+		stateStore: sm.NewStore(stateDB, sm.StoreOptions{
+			DiscardABCIResponses: false,
+		}),
+		blockStore:   store.NewBlockStore(blockStoreDB),
+		txIndexer:    txIndexer,
+		blockIndexer: blockIndexer,
+
+		dbs: dbs,
+	}, nil
+}
+
+// closeDBs closes all the given databases, logging any error
+func closeDBs(dbs []dbm.DB) {
+	for _, db := range dbs {
+		err := db.Close()
+		if err != nil {
+			zerolog.Error().Err(err).Msg("error while closing local node database")
+		}
+	}
+}
+
 func initDBs(config *cfg.Config, dbProvider tmnode.DBProvider) (blockStore *store.BlockStore, stateDB dbm.DB, err error) {
 	var blockStoreDB dbm.DB
 	blockStoreDB, err = dbProvider(&tmnode.DBContext{ID: "blockstore", Config: config})
@@ -305,6 +388,10 @@ func (cp *Node) Genesis() (*tmctypes.ResultGenesis, error) {
 
 // ConsensusState implements node.Node
 func (cp *Node) ConsensusState() (*constypes.RoundStateSimple, error) {
+	if cp.consensusState == nil {
+		return nil, fmt.Errorf("consensus state is not available when using the read-only mode")
+	}
+
 	bz, err := cp.consensusState.GetRoundStateSimpleJSON()
 	if err != nil {
 		return nil, err
@@ -532,4 +619,7 @@ func (cp *Node) SubscribeNewBlocks(subscriber string) (<-chan tmctypes.ResultEve
 
 // Stop implements node.Node
 func (cp *Node) Stop() {
+	// Close the databases opened in read-only mode, which also deletes their snapshots (if any)
+	closeDBs(cp.dbs)
+	cp.dbs = nil
 }
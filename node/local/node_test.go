@@ -0,0 +1,55 @@
+package local_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/node/local"
+)
+
+func TestNode_Stop_RemovesSnapshots(t *testing.T) {
+	home := t.TempDir()
+	dataDir := path.Join(home, "data")
+	require.NoError(t, os.MkdirAll(path.Join(home, "config"), 0700))
+	require.NoError(t, os.WriteFile(path.Join(home, "config", "config.toml"), []byte(`
+[tx_index]
+indexer = "null"
+`), 0600))
+	require.NoError(t, os.WriteFile(path.Join(home, "config", "genesis.json"), []byte(`{
+  "genesis_time": "2023-01-01T00:00:00Z",
+  "chain_id": "test-chain",
+  "initial_height": "1"
+}`), 0600))
+
+	// Open the databases as a running node would, keeping them locked
+	for _, name := range []string{"blockstore", "state"} {
+		db, err := dbm.NewGoLevelDB(name, dataDir)
+		require.NoError(t, err)
+		defer db.Close()
+	}
+
+	// Store the snapshots inside a known directory so that they can be checked
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+
+	details := local.NewDetails(home)
+	details.ReadOnly = true
+	details.DBBackend = string(dbm.GoLevelDBBackend)
+
+	node, err := local.NewNode(details, nil, nil)
+	require.NoError(t, err)
+
+	snapshots, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+
+	node.Stop()
+
+	snapshots, err = os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+}
@@ -0,0 +1,289 @@
+//go:build rocksdb
+// +build rocksdb
+
+package local
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/tecbot/gorocksdb"
+)
+
+func init() {
+	readOnlyOpeners[string(dbm.RocksDBBackend)] = openReadOnlyRocksDB
+}
+
+var (
+	errReadOnly = fmt.Errorf("database is opened in read-only mode")
+
+	_ dbm.DB       = &readOnlyRocksDB{}
+	_ dbm.Batch    = &readOnlyBatch{}
+	_ dbm.Iterator = &readOnlyRocksDBIterator{}
+)
+
+// readOnlyRocksDB represents a RocksDB database that has been opened in read-only mode.
+// RocksDB does not lock the databases opened in read-only mode, so they can be read while a node is running.
+// The data written by the node after the database has been opened is not visible.
+type readOnlyRocksDB struct {
+	db *gorocksdb.DB
+	ro *gorocksdb.ReadOptions
+}
+
+// openReadOnlyRocksDB opens in read-only mode the RocksDB database having the given name inside the given directory
+func openReadOnlyRocksDB(name, dir string) (dbm.DB, error) {
+	db, err := gorocksdb.OpenDbForReadOnly(gorocksdb.NewDefaultOptions(), filepath.Join(dir, name+".db"), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &readOnlyRocksDB{
+		db: db,
+		ro: gorocksdb.NewDefaultReadOptions(),
+	}, nil
+}
+
+// Get implements dbm.DB
+func (db *readOnlyRocksDB) Get(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("key cannot be empty")
+	}
+
+	res, err := db.db.Get(db.ro, key)
+	if err != nil {
+		return nil, err
+	}
+	return moveSliceToBytes(res), nil
+}
+
+// Has implements dbm.DB
+func (db *readOnlyRocksDB) Has(key []byte) (bool, error) {
+	value, err := db.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return value != nil, nil
+}
+
+// Set implements dbm.DB
+func (db *readOnlyRocksDB) Set([]byte, []byte) error {
+	return errReadOnly
+}
+
+// SetSync implements dbm.DB
+func (db *readOnlyRocksDB) SetSync([]byte, []byte) error {
+	return errReadOnly
+}
+
+// Delete implements dbm.DB
+func (db *readOnlyRocksDB) Delete([]byte) error {
+	return errReadOnly
+}
+
+// DeleteSync implements dbm.DB
+func (db *readOnlyRocksDB) DeleteSync([]byte) error {
+	return errReadOnly
+}
+
+// Iterator implements dbm.DB
+func (db *readOnlyRocksDB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, fmt.Errorf("key cannot be empty")
+	}
+	return newReadOnlyRocksDBIterator(db.db.NewIterator(db.ro), start, end, false), nil
+}
+
+// ReverseIterator implements dbm.DB
+func (db *readOnlyRocksDB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, fmt.Errorf("key cannot be empty")
+	}
+	return newReadOnlyRocksDBIterator(db.db.NewIterator(db.ro), start, end, true), nil
+}
+
+// Close implements dbm.DB
+func (db *readOnlyRocksDB) Close() error {
+	db.ro.Destroy()
+	db.db.Close()
+	return nil
+}
+
+// NewBatch implements dbm.DB
+func (db *readOnlyRocksDB) NewBatch() dbm.Batch {
+	return &readOnlyBatch{}
+}
+
+// Print implements dbm.DB
+func (db *readOnlyRocksDB) Print() error {
+	itr, err := db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		fmt.Printf("[%X]:\t[%X]\n", itr.Key(), itr.Value())
+	}
+	return nil
+}
+
+// Stats implements dbm.DB
+func (db *readOnlyRocksDB) Stats() map[string]string {
+	return map[string]string{
+		"rocksdb.stats": db.db.GetProperty("rocksdb.stats"),
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// readOnlyBatch represents a batch that returns an error when any write is performed
+type readOnlyBatch struct{}
+
+// Set implements dbm.Batch
+func (b *readOnlyBatch) Set([]byte, []byte) error {
+	return errReadOnly
+}
+
+// Delete implements dbm.Batch
+func (b *readOnlyBatch) Delete([]byte) error {
+	return errReadOnly
+}
+
+// Write implements dbm.Batch
+func (b *readOnlyBatch) Write() error {
+	return errReadOnly
+}
+
+// WriteSync implements dbm.Batch
+func (b *readOnlyBatch) WriteSync() error {
+	return errReadOnly
+}
+
+// Close implements dbm.Batch
+func (b *readOnlyBatch) Close() error {
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// readOnlyRocksDBIterator represents a dbm.Iterator that iterates over the keys of a RocksDB database
+// within the [start, end) domain
+type readOnlyRocksDBIterator struct {
+	source     *gorocksdb.Iterator
+	start, end []byte
+	isReverse  bool
+	isInvalid  bool
+}
+
+func newReadOnlyRocksDBIterator(source *gorocksdb.Iterator, start, end []byte, isReverse bool) *readOnlyRocksDBIterator {
+	if isReverse {
+		if end == nil {
+			source.SeekToLast()
+		} else {
+			source.Seek(end)
+			if source.Valid() {
+				// Skip the end key, or the first key after it
+				if bytes.Compare(end, moveSliceToBytes(source.Key())) <= 0 {
+					source.Prev()
+				}
+			} else {
+				source.SeekToLast()
+			}
+		}
+	} else {
+		if start == nil {
+			source.SeekToFirst()
+		} else {
+			source.Seek(start)
+		}
+	}
+
+	return &readOnlyRocksDBIterator{
+		source:    source,
+		start:     start,
+		end:       end,
+		isReverse: isReverse,
+	}
+}
+
+// Domain implements dbm.Iterator
+func (itr *readOnlyRocksDBIterator) Domain() ([]byte, []byte) {
+	return itr.start, itr.end
+}
+
+// Valid implements dbm.Iterator
+func (itr *readOnlyRocksDBIterator) Valid() bool {
+	if itr.isInvalid {
+		return false
+	}
+
+	if itr.source.Err() != nil || !itr.source.Valid() {
+		itr.isInvalid = true
+		return false
+	}
+
+	key := moveSliceToBytes(itr.source.Key())
+	if itr.isReverse && itr.start != nil && bytes.Compare(key, itr.start) < 0 {
+		itr.isInvalid = true
+		return false
+	}
+	if !itr.isReverse && itr.end != nil && bytes.Compare(itr.end, key) <= 0 {
+		itr.isInvalid = true
+		return false
+	}
+
+	return true
+}
+
+// Key implements dbm.Iterator
+func (itr *readOnlyRocksDBIterator) Key() []byte {
+	itr.assertIsValid()
+	return moveSliceToBytes(itr.source.Key())
+}
+
+// Value implements dbm.Iterator
+func (itr *readOnlyRocksDBIterator) Value() []byte {
+	itr.assertIsValid()
+	return moveSliceToBytes(itr.source.Value())
+}
+
+// Next implements dbm.Iterator
+func (itr *readOnlyRocksDBIterator) Next() {
+	itr.assertIsValid()
+	if itr.isReverse {
+		itr.source.Prev()
+	} else {
+		itr.source.Next()
+	}
+}
+
+// Error implements dbm.Iterator
+func (itr *readOnlyRocksDBIterator) Error() error {
+	return itr.source.Err()
+}
+
+// Close implements dbm.Iterator
+func (itr *readOnlyRocksDBIterator) Close() error {
+	itr.source.Close()
+	return nil
+}
+
+func (itr *readOnlyRocksDBIterator) assertIsValid() {
+	if !itr.Valid() {
+		panic("iterator is invalid")
+	}
+}
+
+// moveSliceToBytes frees the given slice, returning a copy of its data
+func moveSliceToBytes(s *gorocksdb.Slice) []byte {
+	defer s.Free()
+	if !s.Exists() {
+		return nil
+	}
+
+	v := make([]byte, len(s.Data()))
+	copy(v, s.Data())
+	return v
+}
@@ -0,0 +1,168 @@
+package local
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// snapshotAttempts represents the number of times a snapshot is attempted before giving up.
+// Taking a snapshot can fail if the running node compacts the database while the files are being linked.
+const snapshotAttempts = 5
+
+// isLockedErr tells whether the given error has been returned because the database is locked by another process
+func isLockedErr(err error) bool {
+	return errors.Is(err, syscall.EWOULDBLOCK) || errors.Is(err, syscall.EAGAIN)
+}
+
+var (
+	_ dbm.DB = &snapshotDB{}
+)
+
+// snapshotDB represents a read-only database that has been opened from a snapshot of another database.
+// The snapshot is deleted when the database is closed.
+type snapshotDB struct {
+	dbm.DB
+	dir string
+}
+
+// Close implements dbm.DB
+func (db *snapshotDB) Close() error {
+	err := db.DB.Close()
+	if removeErr := os.RemoveAll(db.dir); err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// openGoLevelDBSnapshot opens in read-only mode a snapshot of the goleveldb database having the given name inside
+// the given directory. This allows to read a database that is locked by a running node, as the database itself is
+// never opened. The snapshot reflects the data that has been written by the node up to the moment it was taken.
+func openGoLevelDBSnapshot(name, dir string) (dbm.DB, error) {
+	snapshotDir, err := os.MkdirTemp("", fmt.Sprintf("juno-%s-*", name))
+	if err != nil {
+		return nil, fmt.Errorf("error while creating %s database snapshot directory: %s", name, err)
+	}
+
+	for attempt := 1; attempt <= snapshotAttempts; attempt++ {
+		err = snapshotGoLevelDB(filepath.Join(dir, name+".db"), filepath.Join(snapshotDir, name+".db"))
+
+		// Links failing for other reasons than concurrent compactions will keep failing, so do not retry them
+		var linkErr *linkError
+		if err == nil || errors.As(err, &linkErr) {
+			break
+		}
+	}
+	if err != nil {
+		os.RemoveAll(snapshotDir)
+		return nil, fmt.Errorf("error while taking %s database snapshot: %s", name, err)
+	}
+
+	db, err := dbm.NewGoLevelDBWithOpts(name, snapshotDir, &opt.Options{
+		ReadOnly:       true,
+		ErrorIfMissing: true,
+	})
+	if err != nil {
+		os.RemoveAll(snapshotDir)
+		return nil, fmt.Errorf("error while opening %s database snapshot: %s", name, err)
+	}
+
+	return &snapshotDB{DB: db, dir: snapshotDir}, nil
+}
+
+// snapshotGoLevelDB takes a snapshot of the goleveldb database present inside the src directory, storing it inside
+// the dst directory. The tables are immutable, so they are hard linked when possible, while the manifest and the
+// journals are copied. Files that are added after the manifest has been copied are simply ignored when opening the
+// snapshot, while files that are removed in the meantime make the snapshot fail, so that it can be attempted again.
+func snapshotGoLevelDB(src, dst string) error {
+	err := os.RemoveAll(dst)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dst, 0755)
+	if err != nil {
+		return err
+	}
+
+	current, err := os.ReadFile(filepath.Join(src, "CURRENT"))
+	if err != nil {
+		return err
+	}
+
+	manifest := strings.TrimSpace(string(current))
+	err = copyFile(filepath.Join(src, manifest), filepath.Join(dst, manifest))
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		fileName := entry.Name()
+		switch filepath.Ext(fileName) {
+		case ".ldb", ".sst":
+			err = linkFile(filepath.Join(src, fileName), filepath.Join(dst, fileName))
+		case ".log":
+			err = copyFile(filepath.Join(src, fileName), filepath.Join(dst, fileName))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Write the CURRENT file last, so that the snapshot can only be opened if it is complete
+	return os.WriteFile(filepath.Join(dst, "CURRENT"), current, 0644)
+}
+
+// linkFile creates a hard link of the src file at the dst path.
+// Tables are never copied, since they can be several gigabytes: if a link cannot be created (eg. because the
+// snapshot is stored on another file system), an error is returned instead.
+func linkFile(src, dst string) error {
+	err := os.Link(src, dst)
+	if err == nil || os.IsNotExist(err) {
+		return err
+	}
+	return &linkError{err: err}
+}
+
+// linkError represents the error returned when a table cannot be hard linked inside the snapshot directory
+type linkError struct {
+	err error
+}
+
+// Error implements error
+func (e *linkError) Error() string {
+	return fmt.Sprintf("%s: the snapshot directory must be on the same file system of the database "+
+		"(set the TMPDIR environment variable accordingly)", e.err)
+}
+
+// copyFile copies the content of the src file into the dst file
+func copyFile(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dstFile, srcFile)
+	if closeErr := dstFile.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
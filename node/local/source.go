@@ -3,7 +3,6 @@ package local
 import (
 	"fmt"
 	"os"
-	"path"
 	"reflect"
 	"unsafe"
 
@@ -11,14 +10,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 
-	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/log"
-	tmnode "github.com/cometbft/cometbft/node"
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	tmstore "github.com/cometbft/cometbft/store"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/spf13/viper"
 
 	"github.com/forbole/juno/v5/node"
 )
@@ -42,50 +38,37 @@ type Source struct {
 
 // NewSource returns a new Source instance
 func NewSource(home string, codec codec.Codec) (*Source, error) {
-	levelDB, err := db.NewGoLevelDB("application", path.Join(home, "data"))
+	return NewSourceFromDetails(NewDetails(home), codec)
+}
+
+// NewSourceFromDetails returns a new Source instance reading the data of the node described by the given details
+func NewSourceFromDetails(details *Details, codec codec.Codec) (*Source, error) {
+	tmCfg, err := loadConfig(details)
 	if err != nil {
 		return nil, err
 	}
 
-	tmCfg, err := parseConfig(home)
+	appDB, err := openDB("application", tmCfg.DBBackend, details.GetApplicationDBDir(), details.ReadOnly)
 	if err != nil {
 		return nil, err
 	}
 
-	blockStoreDB, err := tmnode.DefaultDBProvider(&tmnode.DBContext{ID: "blockstore", Config: tmCfg})
+	blockStoreDB, err := openDB("blockstore", tmCfg.DBBackend, tmCfg.DBDir(), details.ReadOnly)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Source{
-		StoreDB: levelDB,
+		StoreDB: appDB,
 
 		Codec: codec,
 
 		BlockStore: tmstore.NewBlockStore(blockStoreDB),
 		Logger:     log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "explorer"),
-		Cms:        store.NewCommitMultiStore(levelDB),
+		Cms:        store.NewCommitMultiStore(appDB),
 	}, nil
 }
 
-func parseConfig(home string) (*cfg.Config, error) {
-	viper.SetConfigFile(path.Join(home, "config", "config.toml"))
-
-	conf := cfg.DefaultConfig()
-	err := viper.Unmarshal(conf)
-	if err != nil {
-		return nil, err
-	}
-	conf.SetRoot(conf.RootDir)
-
-	err = conf.ValidateBasic()
-	if err != nil {
-		return nil, fmt.Errorf("error in config file: %v", err)
-	}
-
-	return conf, nil
-}
-
 // Type implements keeper.Source
 func (k Source) Type() string {
 	return node.LocalKeeper
@@ -0,0 +1,88 @@
+package local_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/node/local"
+)
+
+func TestNewSourceFromDetails(t *testing.T) {
+	home := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(home, "config"), 0700))
+	require.NoError(t, os.WriteFile(path.Join(home, "config", "config.toml"), []byte(`
+db_backend = "testdb"
+db_dir = "custom-data"
+`), 0600))
+
+	openedDirs := map[string]string{}
+	local.RegisterDBBackend("testdb", func(name, dir string, readOnly bool) (dbm.DB, error) {
+		require.True(t, readOnly)
+		openedDirs[name] = dir
+		return dbm.NewMemDB(), nil
+	})
+
+	// The backend and the blockstore directory should be read from the config.toml file
+	details := local.NewDetails(home)
+	details.ReadOnly = true
+	details.ApplicationDBDir = "/app-data"
+
+	_, err := local.NewSourceFromDetails(details, nil)
+	require.NoError(t, err)
+	require.Equal(t, "/app-data", openedDirs["application"])
+	require.Equal(t, path.Join(home, "custom-data"), openedDirs["blockstore"])
+
+	// Values set inside the details should override the config.toml ones
+	var otherBackendUsed bool
+	local.RegisterDBBackend("otherdb", func(name, dir string, readOnly bool) (dbm.DB, error) {
+		otherBackendUsed = true
+		openedDirs[name] = dir
+		return dbm.NewMemDB(), nil
+	})
+
+	details.BlockStoreDBDir = "/blockstore-data"
+	details.DBBackend = "otherdb"
+
+	_, err = local.NewSourceFromDetails(details, nil)
+	require.NoError(t, err)
+	require.True(t, otherBackendUsed)
+	require.Equal(t, "/blockstore-data", openedDirs["blockstore"])
+}
+
+func TestNewSourceFromDetails_LockedDatabases(t *testing.T) {
+	home := t.TempDir()
+	dataDir := path.Join(home, "data")
+
+	// Open the databases as a running node would, keeping them locked
+	appDB, err := dbm.NewGoLevelDB("application", dataDir)
+	require.NoError(t, err)
+	defer appDB.Close()
+	require.NoError(t, appDB.Set([]byte("key"), []byte("value")))
+
+	blockStoreDB, err := dbm.NewGoLevelDB("blockstore", dataDir)
+	require.NoError(t, err)
+	defer blockStoreDB.Close()
+
+	details := local.NewDetails(home)
+	details.ReadOnly = true
+	details.DBBackend = string(dbm.GoLevelDBBackend)
+
+	source, err := local.NewSourceFromDetails(details, nil)
+	require.NoError(t, err)
+
+	value, err := source.StoreDB.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	// Writes done by the node after the snapshot has been taken should not be visible
+	require.NoError(t, appDB.Set([]byte("other"), []byte("value")))
+	value, err = source.StoreDB.Get([]byte("other"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	require.NoError(t, source.StoreDB.Close())
+}
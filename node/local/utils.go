@@ -2,6 +2,9 @@ package local
 
 import (
 	"fmt"
+	"os"
+	"path"
+	"strings"
 	"time"
 
 	cfg "github.com/cometbft/cometbft/config"
@@ -33,6 +36,40 @@ func ParseConfig() (*cfg.Config, error) {
 	return conf, nil
 }
 
+// loadConfig loads the CometBFT configuration of the node described by the given details.
+// The values are read from the config.toml file present inside the home folder (if any), and then
+// overridden by the ones explicitly set inside the given details.
+func loadConfig(details *Details) (*cfg.Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path.Join(details.Home, "config", "config.toml"))
+	err := v.ReadInConfig()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error while reading config file: %s", err)
+	}
+
+	conf := cfg.DefaultConfig()
+	err = v.Unmarshal(conf)
+	if err != nil {
+		return nil, err
+	}
+	conf.SetRoot(details.Home)
+
+	if strings.TrimSpace(details.DBBackend) != "" {
+		conf.DBBackend = details.DBBackend
+	}
+
+	if strings.TrimSpace(details.BlockStoreDBDir) != "" {
+		conf.DBPath = details.BlockStoreDBDir
+	}
+
+	err = conf.ValidateBasic()
+	if err != nil {
+		return nil, fmt.Errorf("error in config file: %v", err)
+	}
+
+	return conf, nil
+}
+
 // Deprecated: this interface is used only internally for scenario we are
 // deprecating (StdTxConfig support)
 type intoAny interface {
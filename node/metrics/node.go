@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"context"
+
+	constypes "github.com/cometbft/cometbft/consensus/types"
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/forbole/juno/v5/logging"
+	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/types"
+)
+
+var (
+	_ node.Node = &Node{}
+)
+
+// Node represents a node.Node decorator that tracks the time spent calling each method of the wrapped node
+type Node struct {
+	node.Node
+}
+
+// NewNode returns a new Node instance wrapping the given node
+func NewNode(wrapped node.Node) *Node {
+	return &Node{
+		Node: wrapped,
+	}
+}
+
+// Unwrap returns the wrapped node, allowing to access its concrete type
+func (n *Node) Unwrap() node.Node {
+	return n.Node
+}
+
+// observe returns a timer tracking the time spent calling the node method having the given name
+func observe(method string) *prometheus.Timer {
+	return prometheus.NewTimer(logging.NodeCallDuration.WithLabelValues(method))
+}
+
+// Genesis implements node.Node
+func (n *Node) Genesis() (*tmctypes.ResultGenesis, error) {
+	defer observe("genesis").ObserveDuration()
+	return n.Node.Genesis()
+}
+
+// ConsensusState implements node.Node
+func (n *Node) ConsensusState() (*constypes.RoundStateSimple, error) {
+	defer observe("consensus_state").ObserveDuration()
+	return n.Node.ConsensusState()
+}
+
+// LatestHeight implements node.Node
+func (n *Node) LatestHeight() (int64, error) {
+	defer observe("latest_height").ObserveDuration()
+	return n.Node.LatestHeight()
+}
+
+// ChainID implements node.Node
+func (n *Node) ChainID() (string, error) {
+	defer observe("chain_id").ObserveDuration()
+	return n.Node.ChainID()
+}
+
+// Validators implements node.Node
+func (n *Node) Validators(height int64) (*tmctypes.ResultValidators, error) {
+	defer observe("validators").ObserveDuration()
+	return n.Node.Validators(height)
+}
+
+// Block implements node.Node
+func (n *Node) Block(height int64) (*tmctypes.ResultBlock, error) {
+	defer observe("block").ObserveDuration()
+	return n.Node.Block(height)
+}
+
+// BlockResults implements node.Node
+func (n *Node) BlockResults(height int64) (*tmctypes.ResultBlockResults, error) {
+	defer observe("block_results").ObserveDuration()
+	return n.Node.BlockResults(height)
+}
+
+// Tx implements node.Node
+func (n *Node) Tx(hash string) (*types.Transaction, error) {
+	defer observe("tx").ObserveDuration()
+	return n.Node.Tx(hash)
+}
+
+// Txs implements node.Node
+func (n *Node) Txs(block *tmctypes.ResultBlock) ([]*types.Transaction, error) {
+	defer observe("txs").ObserveDuration()
+	return n.Node.Txs(block)
+}
+
+// TxSearch implements node.Node
+func (n *Node) TxSearch(query string, page *int, perPage *int, orderBy string) (*tmctypes.ResultTxSearch, error) {
+	defer observe("tx_search").ObserveDuration()
+	return n.Node.TxSearch(query, page, perPage, orderBy)
+}
+
+// SubscribeEvents implements node.Node
+func (n *Node) SubscribeEvents(subscriber, query string) (<-chan tmctypes.ResultEvent, context.CancelFunc, error) {
+	defer observe("subscribe_events").ObserveDuration()
+	return n.Node.SubscribeEvents(subscriber, query)
+}
+
+// SubscribeNewBlocks implements node.Node
+func (n *Node) SubscribeNewBlocks(subscriber string) (<-chan tmctypes.ResultEvent, context.CancelFunc, error) {
+	defer observe("subscribe_new_blocks").ObserveDuration()
+	return n.Node.SubscribeNewBlocks(subscriber)
+}
@@ -0,0 +1,58 @@
+package metrics_test
+
+import (
+	"testing"
+
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/logging"
+	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/node/metrics"
+)
+
+// mockNode represents a node.Node implementation returning fixed values
+type mockNode struct {
+	node.Node
+}
+
+func (n *mockNode) LatestHeight() (int64, error) {
+	return 10, nil
+}
+
+func (n *mockNode) Block(height int64) (*tmctypes.ResultBlock, error) {
+	return &tmctypes.ResultBlock{Block: &tmtypes.Block{Header: tmtypes.Header{Height: height}}}, nil
+}
+
+func TestNode(t *testing.T) {
+	wrapped := &mockNode{}
+	instrumented := metrics.NewNode(wrapped)
+	require.Equal(t, wrapped, instrumented.Unwrap())
+
+	height, err := instrumented.LatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(10), height)
+
+	for i := int64(1); i <= 3; i++ {
+		block, err := instrumented.Block(i)
+		require.NoError(t, err)
+		require.Equal(t, i, block.Block.Height)
+	}
+
+	// Each method should be tracked separately
+	require.Equal(t, 2, testutil.CollectAndCount(logging.NodeCallDuration))
+	require.Equal(t, uint64(1), getSampleCount(t, "latest_height"))
+	require.Equal(t, uint64(3), getSampleCount(t, "block"))
+}
+
+// getSampleCount returns the number of observed calls of the node method having the given name
+func getSampleCount(t *testing.T, method string) uint64 {
+	var metric dto.Metric
+	histogram := logging.NodeCallDuration.WithLabelValues(method).(prometheus.Histogram)
+	require.NoError(t, histogram.Write(&metric))
+	return metric.GetHistogram().GetSampleCount()
+}
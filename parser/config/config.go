@@ -11,6 +11,18 @@ type Config struct {
 	ParseOldBlocks  bool           `yaml:"parse_old_blocks"`
 	ParseGenesis    bool           `yaml:"parse_genesis"`
 	FastSync        bool           `yaml:"fast_sync,omitempty"`
+
+	// ValidatorsCacheSize represents the number of validator sets that are cached among workers.
+	// Any value less or equal to 0 means to use the default size instead.
+	ValidatorsCacheSize int `yaml:"validators_cache_size,omitempty"`
+
+	// ConcurrentModules contains the configuration used to run the modules handlers concurrently.
+	// If nil or not enabled, the modules handlers are called one after the other.
+	ConcurrentModules *ConcurrencyConfig `yaml:"concurrent_modules,omitempty"`
+
+	// Quarantine contains the configuration used to disable the modules that keep failing.
+	// If nil, modules are never disabled.
+	Quarantine *QuarantineConfig `yaml:"quarantine,omitempty"`
 }
 
 // NewParsingConfig allows to build a new Config instance
@@ -47,3 +59,59 @@ func DefaultParsingConfig() Config {
 		&avgBlockTime,
 	)
 }
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// ConcurrencyConfig contains the configuration used to run the modules handlers concurrently
+type ConcurrencyConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Timeout represents the default maximum time that each module can take to handle a single height.
+	// Any value less or equal to 0 means no timeout.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+
+	// MaxGoroutines represents the default maximum number of heights that each module can handle at the same time.
+	// Any value less or equal to 0 means to use 1 instead.
+	MaxGoroutines int `yaml:"max_goroutines,omitempty"`
+
+	// Modules contains the values that override the default ones for specific modules
+	Modules map[string]ModuleConcurrencyConfig `yaml:"modules,omitempty"`
+}
+
+// ModuleConcurrencyConfig contains the concurrency configuration of a single module
+type ModuleConcurrencyConfig struct {
+	Timeout       time.Duration `yaml:"timeout,omitempty"`
+	MaxGoroutines int           `yaml:"max_goroutines,omitempty"`
+}
+
+// GetTimeout returns the timeout to be used for the module having the given name
+func (c *ConcurrencyConfig) GetTimeout(moduleName string) time.Duration {
+	if moduleCfg, ok := c.Modules[moduleName]; ok && moduleCfg.Timeout > 0 {
+		return moduleCfg.Timeout
+	}
+	return c.Timeout
+}
+
+// GetMaxGoroutines returns the max number of goroutines to be used for the module having the given name
+func (c *ConcurrencyConfig) GetMaxGoroutines(moduleName string) int {
+	if moduleCfg, ok := c.Modules[moduleName]; ok && moduleCfg.MaxGoroutines > 0 {
+		return moduleCfg.MaxGoroutines
+	}
+	if c.MaxGoroutines > 0 {
+		return c.MaxGoroutines
+	}
+	return 1
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// QuarantineConfig contains the configuration used to quarantine the modules that keep failing
+type QuarantineConfig struct {
+	// MaxFailures represents the max number of failures (errors or panics) that a module can have
+	// inside the window before being quarantined. Any value less or equal to 0 means no quarantine.
+	MaxFailures int `yaml:"max_failures"`
+
+	// Window represents the period of time inside which the failures are counted.
+	// Any value less or equal to 0 means that all the failures are counted.
+	Window time.Duration `yaml:"window,omitempty"`
+}
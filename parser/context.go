@@ -6,6 +6,8 @@ import (
 
 	"github.com/forbole/juno/v5/database"
 	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/types"
+	"github.com/forbole/juno/v5/types/config"
 )
 
 // Context represents the context that is shared among different workers
@@ -14,17 +16,62 @@ type Context struct {
 	Database database.Database
 	Logger   logging.Logger
 	Modules  []modules.Module
+
+	// MessageRouter allows to get the modules that should handle each message type
+	MessageRouter *modules.MessageRouter
+
+	// ValidatorsCache contains the hashes of the validator sets already stored by any of the workers
+	ValidatorsCache *ValidatorsCache
+
+	// ModulesScheduler is used to run the modules handlers concurrently.
+	// If nil, the modules handlers are called one after the other.
+	ModulesScheduler *ModulesScheduler
+
+	// ModulesQuarantine keeps track of the modules failures shared among all the workers
+	ModulesQuarantine *ModulesQuarantine
+
+	// ParsingStatus keeps track of the heights parsed by the workers
+	ParsingStatus *types.ParsingStatus
 }
 
 // NewContext builds a new Context instance
 func NewContext(
 	proxy node.Node, db database.Database,
-	logger logging.Logger, modules []modules.Module,
+	logger logging.Logger, mods []modules.Module,
 ) *Context {
+	var scheduler *ModulesScheduler
+	if concurrencyCfg := config.Cfg.Parser.ConcurrentModules; concurrencyCfg != nil && concurrencyCfg.Enabled {
+		scheduler = NewModulesScheduler(concurrencyCfg)
+	}
+
 	return &Context{
 		Node:     proxy,
 		Database: db,
-		Modules:  modules,
+		Modules:  mods,
 		Logger:   logger,
+
+		MessageRouter:     modules.NewMessageRouter(mods),
+		ValidatorsCache:   NewValidatorsCache(config.Cfg.Parser.ValidatorsCacheSize),
+		ModulesScheduler:  scheduler,
+		ModulesQuarantine: NewModulesQuarantine(config.Cfg.Parser.Quarantine),
+		ParsingStatus:     types.NewParsingStatus(),
+	}
+}
+
+// InjectModulesDependencies provides the modules of this context implementing modules.DatabaseAwareModule,
+// modules.NodeAwareModule and modules.ParsingStatusAwareModule with the corresponding values
+func (c *Context) InjectModulesDependencies() {
+	for _, module := range c.Modules {
+		if module, ok := module.(modules.DatabaseAwareModule); ok {
+			module.SetDatabase(c.Database)
+		}
+
+		if module, ok := module.(modules.NodeAwareModule); ok {
+			module.SetNode(c.Node)
+		}
+
+		if module, ok := module.(modules.ParsingStatusAwareModule); ok {
+			module.SetParsingStatus(c.ParsingStatus)
+		}
 	}
 }
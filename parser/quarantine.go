@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	parserconfig "github.com/forbole/juno/v5/parser/config"
+)
+
+// ModulesQuarantine keeps track of the failures of each module, and quarantines the modules that fail
+// too many times inside the configured window. Quarantined modules are no longer called by the workers.
+// It is safe to be shared among different workers.
+type ModulesQuarantine struct {
+	cfg *parserconfig.QuarantineConfig
+
+	mu          sync.Mutex
+	failures    map[string][]time.Time
+	quarantined map[string]bool
+}
+
+// NewModulesQuarantine returns a new ModulesQuarantine instance using the given configuration.
+// If the configuration is nil, modules are never quarantined.
+func NewModulesQuarantine(cfg *parserconfig.QuarantineConfig) *ModulesQuarantine {
+	return &ModulesQuarantine{
+		cfg:         cfg,
+		failures:    make(map[string][]time.Time),
+		quarantined: make(map[string]bool),
+	}
+}
+
+// IsQuarantined tells whether the module having the given name has been quarantined
+func (q *ModulesQuarantine) IsQuarantined(moduleName string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.quarantined[moduleName]
+}
+
+// Quarantined returns the names of all the modules that have been quarantined, sorted alphabetically
+func (q *ModulesQuarantine) Quarantined() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var names []string
+	for name := range q.quarantined {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RecordFailure records a failure of the module having the given name.
+// It returns true if the module has been quarantined because of this failure.
+func (q *ModulesQuarantine) RecordFailure(moduleName string) bool {
+	if q.cfg == nil || q.cfg.MaxFailures <= 0 {
+		return false
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.quarantined[moduleName] {
+		return false
+	}
+
+	now := time.Now()
+
+	// Only keep the failures that are inside the window
+	failures := append(q.failures[moduleName], now)
+	if q.cfg.Window > 0 {
+		var recent []time.Time
+		for _, failure := range failures {
+			if now.Sub(failure) <= q.cfg.Window {
+				recent = append(recent, failure)
+			}
+		}
+		failures = recent
+	}
+	q.failures[moduleName] = failures
+
+	if len(failures) > q.cfg.MaxFailures {
+		q.quarantined[moduleName] = true
+		delete(q.failures, moduleName)
+		return true
+	}
+
+	return false
+}
+
+// panicError represents the error returned when a handler panics
+type panicError struct {
+	value interface{}
+	stack []byte
+}
+
+// Error implements error
+func (e *panicError) Error() string {
+	return fmt.Sprintf("recovered from panic: %v\n%s", e.value, e.stack)
+}
+
+// callHandler calls the given handler, converting any panic into a *panicError that contains the stack trace
+func callHandler(handler func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &panicError{value: r, stack: debug.Stack()}
+		}
+	}()
+
+	return handler()
+}
@@ -0,0 +1,42 @@
+package parser_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/parser"
+	parserconfig "github.com/forbole/juno/v5/parser/config"
+)
+
+func TestModulesQuarantine_RecordFailure(t *testing.T) {
+	quarantine := parser.NewModulesQuarantine(&parserconfig.QuarantineConfig{
+		MaxFailures: 2,
+		Window:      time.Minute,
+	})
+
+	// The module should be quarantined only after exceeding the max number of failures
+	require.False(t, quarantine.RecordFailure("bank"))
+	require.False(t, quarantine.RecordFailure("bank"))
+	require.False(t, quarantine.IsQuarantined("bank"))
+
+	require.True(t, quarantine.RecordFailure("bank"))
+	require.True(t, quarantine.IsQuarantined("bank"))
+
+	// Following failures should not quarantine the module again
+	require.False(t, quarantine.RecordFailure("bank"))
+
+	// Other modules should not be affected
+	require.False(t, quarantine.IsQuarantined("auth"))
+	require.Equal(t, []string{"bank"}, quarantine.Quarantined())
+}
+
+func TestModulesQuarantine_Disabled(t *testing.T) {
+	quarantine := parser.NewModulesQuarantine(nil)
+	for i := 0; i < 100; i++ {
+		require.False(t, quarantine.RecordFailure("bank"))
+	}
+	require.False(t, quarantine.IsQuarantined("bank"))
+	require.Empty(t, quarantine.Quarantined())
+}
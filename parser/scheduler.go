@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/forbole/juno/v5/modules"
+	parserconfig "github.com/forbole/juno/v5/parser/config"
+)
+
+// ModulesScheduler allows to run the handlers of different modules concurrently.
+// It is safe to be shared among different workers, so that the max number of goroutines of each module
+// is respected across all of them.
+type ModulesScheduler struct {
+	cfg *parserconfig.ConcurrencyConfig
+
+	mu         sync.Mutex
+	semaphores map[string]chan struct{}
+}
+
+// NewModulesScheduler returns a new ModulesScheduler instance using the given configuration
+func NewModulesScheduler(cfg *parserconfig.ConcurrencyConfig) *ModulesScheduler {
+	return &ModulesScheduler{
+		cfg:        cfg,
+		semaphores: make(map[string]chan struct{}),
+	}
+}
+
+// getSemaphore returns the semaphore limiting the number of goroutines of the module having the given name
+func (s *ModulesScheduler) getSemaphore(moduleName string) chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	semaphore, ok := s.semaphores[moduleName]
+	if !ok {
+		semaphore = make(chan struct{}, s.cfg.GetMaxGoroutines(moduleName))
+		s.semaphores[moduleName] = semaphore
+	}
+	return semaphore
+}
+
+// Run calls run for each one of the given modules, running independent modules concurrently.
+// Modules implementing the DependentModule interface are only run after all their dependencies have completed.
+// The context passed to run is cancelled once the module timeout expires, and run should stop as soon as possible
+// after that. Any error returned by run, any panic and any timeout is passed to onError.
+// This method returns only after all the modules have completed, so that no handler is ever left running in background.
+func (s *ModulesScheduler) Run(
+	mods []modules.Module,
+	run func(ctx context.Context, module modules.Module) error,
+	onError func(module modules.Module, err error),
+) {
+	done := make(map[string]chan struct{}, len(mods))
+	for _, module := range mods {
+		done[strings.ToLower(module.Name())] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for _, module := range mods {
+		wg.Add(1)
+		go func(module modules.Module) {
+			defer wg.Done()
+			defer close(done[strings.ToLower(module.Name())])
+
+			// Wait for all the dependencies to complete
+			if dependentModule, ok := module.(modules.DependentModule); ok {
+				for _, dependency := range dependentModule.DependsOn() {
+					if dependencyDone, ok := done[strings.ToLower(dependency)]; ok {
+						<-dependencyDone
+					}
+				}
+			}
+
+			err := s.runModule(module, run)
+			if err != nil {
+				onError(module, err)
+			}
+		}(module)
+	}
+
+	wg.Wait()
+}
+
+// runModule calls run for the given module, respecting its max number of goroutines and its timeout.
+// NOTE. Since handlers cannot be interrupted, a module that times out is only stopped once run returns.
+// For this reason, run should check the given context between the calls to the module handlers.
+func (s *ModulesScheduler) runModule(
+	module modules.Module, run func(ctx context.Context, module modules.Module) error,
+) error {
+	ctx := context.Background()
+	timeout := s.cfg.GetTimeout(module.Name())
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	semaphore := s.getSemaphore(module.Name())
+	select {
+	case semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return fmt.Errorf("timed out after %s while waiting for a free goroutine", timeout)
+	}
+	defer func() { <-semaphore }()
+
+	err := callHandler(func() error { return run(ctx, module) })
+	if ctx.Err() != nil {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package parser_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/parser"
+	parserconfig "github.com/forbole/juno/v5/parser/config"
+)
+
+// testModule represents a modules.DependentModule used for testing purposes
+type testModule struct {
+	name      string
+	dependsOn []string
+}
+
+func (m testModule) Name() string {
+	return m.name
+}
+
+func (m testModule) DependsOn() []string {
+	return m.dependsOn
+}
+
+func TestModulesScheduler_Run(t *testing.T) {
+	scheduler := parser.NewModulesScheduler(&parserconfig.ConcurrencyConfig{
+		Enabled: true,
+		Timeout: 100 * time.Millisecond,
+		Modules: map[string]parserconfig.ModuleConcurrencyConfig{
+			"slow": {Timeout: 10 * time.Millisecond},
+		},
+	})
+
+	mods := []modules.Module{
+		testModule{name: "auth"},
+		testModule{name: "bank", dependsOn: []string{"auth"}},
+		testModule{name: "panicking"},
+		testModule{name: "slow"},
+		testModule{name: "failing"},
+	}
+
+	var mu sync.Mutex
+	var order []string
+	errs := map[string]error{}
+
+	scheduler.Run(mods, func(ctx context.Context, module modules.Module) error {
+		switch module.Name() {
+		case "auth":
+			// Give dependent modules the chance to run first if dependencies were not respected
+			time.Sleep(20 * time.Millisecond)
+		case "panicking":
+			panic("test panic")
+		case "slow":
+			// The context should be cancelled once the timeout expires
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+			}
+		case "failing":
+			return fmt.Errorf("test error")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, module.Name())
+		return nil
+	}, func(module modules.Module, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs[module.Name()] = err
+	})
+
+	// Dependencies should be respected
+	require.Equal(t, []string{"auth", "bank"}, order)
+
+	// Errors, panics and timeouts should be reported
+	require.Len(t, errs, 3)
+	require.EqualError(t, errs["failing"], "test error")
+	require.True(t, strings.HasPrefix(errs["panicking"].Error(), "recovered from panic: test panic"))
+	require.EqualError(t, errs["slow"], "timed out after 10ms")
+}
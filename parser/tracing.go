@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	attrHeight  = attribute.Key("juno.height")
+	attrTxHash  = attribute.Key("juno.tx_hash")
+	attrModule  = attribute.Key("juno.module")
+	attrHandler = attribute.Key("juno.handler")
+	attrMsgType = attribute.Key("juno.message_type")
+)
+
+// tracer is used to trace the parsing of each height.
+// Unless a tracer provider is set (eg. by the tracing module), no span is recorded.
+var tracer = otel.Tracer("github.com/forbole/juno/v5/parser")
+
+// startSpan starts a new span having the given name and attributes as a child of the current worker span.
+// It returns a copy of the worker whose calls are traced as children of the new span.
+func (w Worker) startSpan(name string, attrs ...attribute.KeyValue) (Worker, trace.Span) {
+	ctx, span := tracer.Start(w.ctx, name, trace.WithAttributes(attrs...))
+	w.ctx = ctx
+	return w, span
+}
+
+// endSpan ends the given span, recording the given error if it is not nil
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
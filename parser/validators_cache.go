@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"container/list"
+	"sync"
+
+	tmbytes "github.com/cometbft/cometbft/libs/bytes"
+)
+
+const (
+	// DefaultValidatorsCacheSize represents the default number of validator sets kept inside a ValidatorsCache
+	DefaultValidatorsCacheSize = 16
+)
+
+// ValidatorsCache represents an LRU cache of the hashes of the validator sets that have already been stored.
+// It is safe to be shared among different workers, and allows to avoid storing the validators inside the database
+// when the validator set does not change between heights.
+//
+// NOTE. Only the hashes are cached, since they do not include the proposer priorities that change at every height.
+// The validators of each height must always be read from the node.
+type ValidatorsCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// NewValidatorsCache returns a new ValidatorsCache instance that keeps at most size validator sets.
+// If size is less or equal to 0, DefaultValidatorsCacheSize is used instead.
+func NewValidatorsCache(size int) *ValidatorsCache {
+	if size <= 0 {
+		size = DefaultValidatorsCacheSize
+	}
+
+	return &ValidatorsCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Has tells whether the validator set having the given hash is stored inside the cache
+func (c *ValidatorsCache) Has(hash tmbytes.HexBytes) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, found := c.entries[hash.String()]
+	if found {
+		c.order.MoveToFront(element)
+	}
+	return found
+}
+
+// Add stores the given validator set hash, marking the set as already stored
+func (c *ValidatorsCache) Add(hash tmbytes.HexBytes) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := hash.String()
+	if element, found := c.entries[key]; found {
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(key)
+
+	// Evict the least recently used set if needed
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(string))
+	}
+}
@@ -0,0 +1,45 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/juno/v5/parser"
+)
+
+func buildValidatorSet() *tmtypes.ValidatorSet {
+	validators := make([]*tmtypes.Validator, 4)
+	for i := range validators {
+		validators[i] = tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), int64(i+1)*10)
+	}
+	return tmtypes.NewValidatorSet(validators)
+}
+
+func TestValidatorsCache_Has(t *testing.T) {
+	hash := buildValidatorSet().Hash()
+
+	cache := parser.NewValidatorsCache(2)
+	require.False(t, cache.Has(hash))
+
+	cache.Add(hash)
+	require.True(t, cache.Has(hash))
+}
+
+func TestValidatorsCache_Eviction(t *testing.T) {
+	first, second, third := buildValidatorSet(), buildValidatorSet(), buildValidatorSet()
+
+	cache := parser.NewValidatorsCache(2)
+	cache.Add(first.Hash())
+	cache.Add(second.Hash())
+
+	// Access the first set so that the second one becomes the least recently used
+	require.True(t, cache.Has(first.Hash()))
+
+	cache.Add(third.Hash())
+	require.True(t, cache.Has(first.Hash()))
+	require.False(t, cache.Has(second.Hash()))
+	require.True(t, cache.Has(third.Hash()))
+}
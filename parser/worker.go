@@ -1,7 +1,9 @@
 package parser
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,15 +14,26 @@ import (
 
 	"github.com/forbole/juno/v5/modules"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
 	tmtypes "github.com/cometbft/cometbft/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/forbole/juno/v5/node"
 	"github.com/forbole/juno/v5/types"
 	"github.com/forbole/juno/v5/types/utils"
 )
 
+const (
+	handlerGenesis = "genesis"
+	handlerBlock   = "block"
+	handlerTx      = "tx"
+	handlerEvent   = "event"
+	handlerMsg     = "msg"
+)
+
 // Worker defines a job consumer that is responsible for getting and
 // aggregating block and associated data and exporting it to a database.
 type Worker struct {
@@ -29,13 +42,41 @@ type Worker struct {
 	queue   types.HeightQueue
 	modules []modules.Module
 
-	node   node.Node
-	db     database.Database
-	logger logging.Logger
+	node       node.Node
+	db         database.Database
+	logger     logging.Logger
+	router     *modules.MessageRouter
+	validators *ValidatorsCache
+	scheduler  *ModulesScheduler
+	quarantine *ModulesQuarantine
+	status     *types.ParsingStatus
+
+	// ctx contains the span under which the calls of the worker are traced
+	ctx context.Context
 }
 
 // NewWorker allows to create a new Worker implementation.
 func NewWorker(ctx *Context, queue types.HeightQueue, index int) Worker {
+	validatorsCache := ctx.ValidatorsCache
+	if validatorsCache == nil {
+		validatorsCache = NewValidatorsCache(config.Cfg.Parser.ValidatorsCacheSize)
+	}
+
+	router := ctx.MessageRouter
+	if router == nil {
+		router = modules.NewMessageRouter(ctx.Modules)
+	}
+
+	quarantine := ctx.ModulesQuarantine
+	if quarantine == nil {
+		quarantine = NewModulesQuarantine(config.Cfg.Parser.Quarantine)
+	}
+
+	status := ctx.ParsingStatus
+	if status == nil {
+		status = types.NewParsingStatus()
+	}
+
 	return Worker{
 		index:   index,
 		node:    ctx.Node,
@@ -43,6 +84,14 @@ func NewWorker(ctx *Context, queue types.HeightQueue, index int) Worker {
 		db:      ctx.Database,
 		modules: ctx.Modules,
 		logger:  ctx.Logger,
+
+		router:     router,
+		validators: validatorsCache,
+		scheduler:  ctx.ModulesScheduler,
+		quarantine: quarantine,
+		status:     status,
+
+		ctx: context.Background(),
 	}
 }
 
@@ -50,12 +99,16 @@ func NewWorker(ctx *Context, queue types.HeightQueue, index int) Worker {
 // given worker queue. Any failed job is logged and re-enqueued.
 func (w Worker) Start() {
 	logging.WorkerCount.Inc()
+	defer logging.WorkerCount.Dec()
+
 	chainID, err := w.node.ChainID()
 	if err != nil {
 		w.logger.Error("error while getting chain ID from the node ", "err", err)
 	}
 
 	for i := range w.queue {
+		logging.QueueDepth.Set(float64(len(w.queue)))
+
 		if err := w.ProcessIfNotExists(i); err != nil {
 			// re-enqueue any failed job after average block time
 			time.Sleep(config.GetAvgBlockTime())
@@ -68,6 +121,7 @@ func (w Worker) Start() {
 		}
 
 		logging.WorkerHeight.WithLabelValues(fmt.Sprintf("%d", w.index), chainID).Set(float64(i))
+		w.status.SetWorkerHeight(w.index, i)
 	}
 }
 
@@ -91,6 +145,14 @@ func (w Worker) ProcessIfNotExists(height int64) error {
 // Process fetches  a block for a given height and associated metadata and export it to a database.
 // It returns an error if any export process fails.
 func (w Worker) Process(height int64) error {
+	w, span := w.startSpan("process", attrHeight.Int64(height))
+	err := w.process(height)
+	endSpan(span, err)
+	return err
+}
+
+// process fetches a block for a given height and associated metadata and export it to a database
+func (w Worker) process(height int64) error {
 	if height == 0 {
 		cfg := config.Cfg.Parser
 
@@ -104,22 +166,30 @@ func (w Worker) Process(height int64) error {
 
 	w.logger.Debug("processing block", "height", height)
 
+	_, span := w.startSpan("node.block")
 	block, err := w.node.Block(height)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("failed to get block from node: %s", err)
 	}
 
+	_, span = w.startSpan("node.block_results")
 	events, err := w.node.BlockResults(height)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("failed to get block results from node: %s", err)
 	}
 
+	_, span = w.startSpan("node.txs")
 	txs, err := w.node.Txs(block)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("failed to get transactions for block: %s", err)
 	}
 
+	_, span = w.startSpan("node.validators")
 	vals, err := w.node.Validators(height)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("failed to get validators for block: %s", err)
 	}
@@ -129,13 +199,22 @@ func (w Worker) Process(height int64) error {
 
 // ProcessTransactions fetches transactions for a given height and stores them into the database.
 // It returns an error if the export process fails.
-func (w Worker) ProcessTransactions(height int64) error {
+func (w Worker) ProcessTransactions(height int64) (err error) {
+	w, span := w.startSpan("process_transactions", attrHeight.Int64(height))
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	_, nodeSpan := w.startSpan("node.block")
 	block, err := w.node.Block(height)
+	endSpan(nodeSpan, err)
 	if err != nil {
 		return fmt.Errorf("failed to get block from node: %s", err)
 	}
 
+	_, nodeSpan = w.startSpan("node.txs")
 	txs, err := w.node.Txs(block)
+	endSpan(nodeSpan, err)
 	if err != nil {
 		return fmt.Errorf("failed to get transactions for block: %s", err)
 	}
@@ -149,9 +228,11 @@ func (w Worker) HandleGenesis(genesisDoc *tmtypes.GenesisDoc, appState map[strin
 	// Call the genesis handlers
 	for _, module := range w.modules {
 		if genesisModule, ok := module.(modules.GenesisModule); ok {
-			if err := genesisModule.HandleGenesis(genesisDoc, appState); err != nil {
+			w.callModuleHandler(module, handlerGenesis, "", func() error {
+				return genesisModule.HandleGenesis(genesisDoc, appState)
+			}, func(err error) {
 				w.logger.GenesisError(module, err)
-			}
+			})
 		}
 	}
 
@@ -174,7 +255,11 @@ func (w Worker) SaveValidators(vals []*tmtypes.Validator) error {
 		validators[index] = types.NewValidator(consAddr, consPubKey)
 	}
 
+	_, span := w.startSpan("db.save_validators")
+	timer := prometheus.NewTimer(logging.DbWriteDuration.WithLabelValues("save_validators"))
 	err := w.db.SaveValidators(validators)
+	timer.ObserveDuration()
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("error while saving validators: %s", err)
 	}
@@ -188,11 +273,15 @@ func (w Worker) SaveValidators(vals []*tmtypes.Validator) error {
 func (w Worker) ExportBlock(
 	b *tmctypes.ResultBlock, r *tmctypes.ResultBlockResults, txs []*types.Transaction, vals *tmctypes.ResultValidators,
 ) error {
-	// Save all validators
-	err := w.SaveValidators(vals.Validators)
-	if err != nil {
-		return err
+	// Save all validators, unless the same validator set has already been stored
+	var err error
+	if !w.validators.Has(b.Block.ValidatorsHash) {
+		err = w.SaveValidators(vals.Validators)
+		if err != nil {
+			return err
+		}
 	}
+	w.validators.Add(b.Block.ValidatorsHash)
 
 	// Make sure the proposer exists
 	proposerAddr := sdk.ConsAddress(b.Block.ProposerAddress)
@@ -202,10 +291,15 @@ func (w Worker) ExportBlock(
 	}
 
 	// Save the block
+	_, span := w.startSpan("db.save_block")
+	timer := prometheus.NewTimer(logging.DbWriteDuration.WithLabelValues("save_block"))
 	err = w.db.SaveBlock(types.NewBlockFromTmBlock(b, sumGasTxs(txs)))
+	timer.ObserveDuration()
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("failed to persist block: %s", err)
 	}
+	logging.ProcessedBlocks.Inc()
 
 	// Save the commits
 	err = w.ExportCommit(b.Block.LastCommit, vals)
@@ -213,18 +307,32 @@ func (w Worker) ExportBlock(
 		return err
 	}
 
+	if w.scheduler != nil {
+		return w.exportConcurrently(b, r, txs, vals)
+	}
+
 	// Call the block handlers
 	for _, module := range w.modules {
-		if blockModule, ok := module.(modules.BlockModule); ok {
-			err = blockModule.HandleBlock(b, r, txs, vals)
-			if err != nil {
-				w.logger.BlockError(module, b, err)
-			}
-		}
+		w.handleModuleBlock(module, b, r, txs, vals)
+	}
+
+	// Call the begin block events handlers
+	for _, module := range w.modules {
+		w.handleModuleBlockEvents(module, b, modules.EventSourceBeginBlock, r.BeginBlockEvents)
 	}
 
 	// Export the transactions
-	return w.ExportTxs(txs)
+	err = w.exportTxs(b, txs)
+	if err != nil {
+		return err
+	}
+
+	// Call the end block events handlers
+	for _, module := range w.modules {
+		w.handleModuleBlockEvents(module, b, modules.EventSourceEndBlock, r.EndBlockEvents)
+	}
+
+	return nil
 }
 
 // ExportCommit accepts a block commitment and a corresponding set of
@@ -253,7 +361,11 @@ func (w Worker) ExportCommit(commit *tmtypes.Commit, vals *tmctypes.ResultValida
 		))
 	}
 
+	_, span := w.startSpan("db.save_commit_signatures")
+	timer := prometheus.NewTimer(logging.DbWriteDuration.WithLabelValues("save_commit_signatures"))
 	err := w.db.SaveCommitSignatures(signatures)
+	timer.ObserveDuration()
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("error while saving commit signatures: %s", err)
 	}
@@ -264,23 +376,33 @@ func (w Worker) ExportCommit(commit *tmtypes.Commit, vals *tmctypes.ResultValida
 // saveTx accepts the transaction and persists it inside the database.
 // An error is returned if the write fails.
 func (w Worker) saveTx(tx *types.Transaction) error {
+	_, span := w.startSpan("db.save_tx", attrTxHash.String(tx.TxHash))
+	timer := prometheus.NewTimer(logging.DbWriteDuration.WithLabelValues("save_tx"))
 	err := w.db.SaveTx(tx)
+	timer.ObserveDuration()
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("failed to handle transaction with hash %s: %s", tx.TxResponse.TxHash, err)
 	}
+
+	logging.ProcessedTxs.Inc()
+	for _, msg := range tx.Tx.Body.Messages {
+		logging.ProcessedMessages.WithLabelValues(msg.GetType()).Inc()
+	}
 	return nil
 }
 
-// handleTx accepts the transaction and calls the tx handlers.
-func (w Worker) handleTx(tx *types.Transaction) {
+// handleTx accepts the transaction and calls the tx handlers, followed by the tx events handlers.
+// The given block is used to report the events handling errors, and can be nil.
+func (w Worker) handleTx(b *tmctypes.ResultBlock, tx *types.Transaction) {
 	// Call the tx handlers
 	for _, module := range w.modules {
-		if transactionModule, ok := module.(modules.TransactionModule); ok {
-			err := transactionModule.HandleTx(tx)
-			if err != nil {
-				w.logger.TxError(module, tx, err)
-			}
-		}
+		w.handleModuleTx(module, tx)
+	}
+
+	// Call the tx events handlers
+	for _, module := range w.modules {
+		w.handleModuleTxEvents(module, b, tx)
 	}
 }
 
@@ -288,19 +410,155 @@ func (w Worker) handleTx(tx *types.Transaction) {
 // inside the transaction.
 func (w Worker) handleMessage(index int, msg types.Message, tx *types.Transaction) {
 	// Allow modules to handle the message
-	for _, module := range w.modules {
-		if messageModule, ok := module.(modules.MessageModule); ok {
-			err := messageModule.HandleMsg(index, msg, tx)
-			if err != nil {
-				w.logger.MsgError(module, tx, msg, err)
-			}
+	for _, module := range w.router.Modules(msg.GetType()) {
+		w.handleModuleMessage(module, index, msg, tx)
+	}
+}
+
+// handleModuleBlock calls the block handler of the given module, if it implements modules.BlockModule
+func (w Worker) handleModuleBlock(
+	module modules.Module,
+	b *tmctypes.ResultBlock, r *tmctypes.ResultBlockResults, txs []*types.Transaction, vals *tmctypes.ResultValidators,
+) {
+	if blockModule, ok := module.(modules.BlockModule); ok {
+		w.callModuleHandler(module, handlerBlock, "", func() error {
+			return blockModule.HandleBlock(b, r, txs, vals)
+		}, func(err error) {
+			w.logger.BlockError(module, b, err)
+		})
+	}
+}
+
+// handleModuleTx calls the tx handler of the given module, if it implements modules.TransactionModule
+func (w Worker) handleModuleTx(module modules.Module, tx *types.Transaction) {
+	if transactionModule, ok := module.(modules.TransactionModule); ok && modules.HandlesTx(module, tx) {
+		w.callModuleHandler(module, handlerTx, "", func() error {
+			return transactionModule.HandleTx(tx)
+		}, func(err error) {
+			w.logger.TxError(module, tx, err)
+		}, attrTxHash.String(tx.TxHash))
+	}
+}
+
+// handleModuleBlockEvents calls the events handler of the given module for all the given block events,
+// if the module implements modules.EventModule
+func (w Worker) handleModuleBlockEvents(
+	module modules.Module, b *tmctypes.ResultBlock, source modules.EventSource, events []abci.Event,
+) {
+	w.handleModuleEvents(module, b.Block.Height, source, "", events, func(err error) {
+		w.logger.EventsError(module, b, err)
+	})
+}
+
+// handleModuleTxEvents calls the events handler of the given module for all the events emitted by the given
+// transaction, if the module implements modules.EventModule.
+// The given block is used to report the errors, and can be nil.
+func (w Worker) handleModuleTxEvents(module modules.Module, b *tmctypes.ResultBlock, tx *types.Transaction) {
+	if tx.TxResponse == nil || tx.TxResponse.TxResponse == nil {
+		return
+	}
+
+	w.handleModuleEvents(module, int64(tx.Height), modules.EventSourceTx, tx.TxHash, tx.Events, func(err error) {
+		if b != nil {
+			w.logger.EventsError(module, b, err)
+			return
 		}
+		w.logger.TxError(module, tx, err)
+	})
+}
+
+// handleModuleEvents calls the events handler of the given module for each one of the given events
+// the module is interested in, if the module implements modules.EventModule
+func (w Worker) handleModuleEvents(
+	module modules.Module, height int64, source modules.EventSource, txHash string, events []abci.Event,
+	logError func(err error),
+) {
+	eventModule, ok := module.(modules.EventModule)
+	if !ok {
+		return
+	}
+
+	for _, event := range events {
+		if !modules.HandlesEvent(eventModule, event.Type) {
+			continue
+		}
+
+		event := event
+		w.callModuleHandler(module, handlerEvent, "", func() error {
+			return eventModule.HandleEvent(height, source, txHash, event)
+		}, logError, attrTxHash.String(txHash))
+	}
+}
+
+// handleModuleMessage calls the message handler of the given module, if it implements modules.MessageModule
+func (w Worker) handleModuleMessage(module modules.Module, index int, msg types.Message, tx *types.Transaction) {
+	if messageModule, ok := module.(modules.MessageModule); ok {
+		w.callModuleHandler(module, handlerMsg, msg.GetType(), func() error {
+			return messageModule.HandleMsg(index, msg, tx)
+		}, func(err error) {
+			w.logger.MsgError(module, tx, msg, err)
+		}, attrTxHash.String(tx.TxHash))
+	}
+}
+
+// callModuleHandler calls the given handler of the given module, unless the module has been quarantined.
+// The time spent inside the handler is tracked using the given handler type and message type (if any),
+// and the handler call is traced using a span having the given additional attributes.
+// Any panic is recovered, and any failure is passed to logError and recorded so that modules
+// that keep failing are quarantined.
+func (w Worker) callModuleHandler(
+	module modules.Module, handlerType string, msgType string, handler func() error, logError func(err error),
+	attrs ...attribute.KeyValue,
+) {
+	if w.quarantine.IsQuarantined(module.Name()) {
+		return
+	}
+
+	attrs = append(attrs, attrModule.String(module.Name()), attrHandler.String(handlerType))
+	if msgType != "" {
+		attrs = append(attrs, attrMsgType.String(msgType))
+	}
+	_, span := w.startSpan(fmt.Sprintf("%s/%s", module.Name(), handlerType), attrs...)
+
+	timer := prometheus.NewTimer(logging.ModuleHandlerDuration.WithLabelValues(module.Name(), handlerType, msgType))
+	err := callHandler(handler)
+	timer.ObserveDuration()
+	endSpan(span, err)
+	if err != nil {
+		logError(err)
+		w.recordModuleFailure(module, err)
+	}
+}
+
+// recordModuleFailure records the given failure of the given module, quarantining it if needed
+func (w Worker) recordModuleFailure(module modules.Module, err error) {
+	var panicErr *panicError
+	if errors.As(err, &panicErr) {
+		logging.ModulePanicCount.WithLabelValues(module.Name()).Inc()
+	}
+
+	if w.quarantine.RecordFailure(module.Name()) {
+		logging.ModuleQuarantined.WithLabelValues(module.Name()).Set(1)
+		w.logger.Error("module has been quarantined after failing too many times and will no longer be called",
+			logging.LogKeyModule, module.Name(),
+		)
 	}
 }
 
 // ExportTxs accepts a slice of transactions and persists then inside the database.
 // An error is returned if the write fails.
 func (w Worker) ExportTxs(txs []*types.Transaction) error {
+	if w.scheduler != nil {
+		return w.exportConcurrently(nil, nil, txs, nil)
+	}
+
+	return w.exportTxs(nil, txs)
+}
+
+// exportTxs persists the given transactions inside the database and calls the modules handlers.
+// The given block is the one containing the transactions, and can be nil.
+// An error is returned if the write fails.
+func (w Worker) exportTxs(b *tmctypes.ResultBlock, txs []*types.Transaction) error {
 	// handle all transactions inside the block
 	for _, tx := range txs {
 		// save the transaction
@@ -310,7 +568,7 @@ func (w Worker) ExportTxs(txs []*types.Transaction) error {
 		}
 
 		// call the tx handlers
-		w.handleTx(tx)
+		w.handleTx(b, tx)
 
 		// call the msg handlers
 		for i, msg := range tx.Tx.Body.Messages {
@@ -318,6 +576,94 @@ func (w Worker) ExportTxs(txs []*types.Transaction) error {
 		}
 	}
 
+	return w.updateDbMetrics()
+}
+
+// exportConcurrently calls the handlers of all the modules using the modules scheduler, persisting the given
+// transactions inside the database along the way. The same order used when running the modules sequentially is kept:
+// each module handles the block and its begin block events (if the block is not nil), then all the transactions are
+// stored, and finally each module handles the transactions with their events and messages, followed by the end
+// block events. Independent modules are run concurrently, and a module that times out stops handling the height.
+// An error is returned if the write fails.
+func (w Worker) exportConcurrently(
+	b *tmctypes.ResultBlock, r *tmctypes.ResultBlockResults, txs []*types.Transaction, vals *tmctypes.ResultValidators,
+) error {
+	if b != nil {
+		w.scheduler.Run(w.modules, func(ctx context.Context, module modules.Module) error {
+			w.handleModuleBlock(module, b, r, txs, vals)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			w.handleModuleBlockEvents(module, b, modules.EventSourceBeginBlock, r.BeginBlockEvents)
+			return nil
+		}, w.onConcurrentModuleError(b, txs))
+	}
+
+	// Save all the transactions before calling their handlers, so that modules can rely on them
+	for _, tx := range txs {
+		err := w.saveTx(tx)
+		if err != nil {
+			return fmt.Errorf("error while storing txs: %s", err)
+		}
+	}
+
+	w.scheduler.Run(w.modules, func(ctx context.Context, module modules.Module) error {
+		for _, tx := range txs {
+			w.handleModuleTx(module, tx)
+			w.handleModuleTxEvents(module, b, tx)
+			for i, msg := range tx.Tx.Body.Messages {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
+				if modules.HandlesMessage(module, msg.GetType()) {
+					w.handleModuleMessage(module, i, msg, tx)
+				}
+			}
+
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+
+		if b != nil {
+			w.handleModuleBlockEvents(module, b, modules.EventSourceEndBlock, r.EndBlockEvents)
+		}
+
+		return nil
+	}, w.onConcurrentModuleError(b, txs))
+
+	return w.updateDbMetrics()
+}
+
+// onConcurrentModuleError returns the function used to handle the errors, panics and timeouts of the modules
+// that have been run concurrently to handle the given block (which can be nil) and transactions
+func (w Worker) onConcurrentModuleError(
+	b *tmctypes.ResultBlock, txs []*types.Transaction,
+) func(module modules.Module, err error) {
+	return func(module modules.Module, err error) {
+		w.recordModuleFailure(module, err)
+
+		if b != nil {
+			w.logger.BlockError(module, b, err)
+			return
+		}
+
+		var height uint64
+		if len(txs) > 0 {
+			height = txs[0].Height
+		}
+		w.logger.Error("error while handling transactions",
+			"err", err,
+			logging.LogKeyModule, module.Name(),
+			logging.LogKeyHeight, height,
+		)
+	}
+}
+
+// updateDbMetrics updates the Prometheus metrics related to the blocks stored inside the database
+func (w Worker) updateDbMetrics() error {
 	totalBlocks := w.db.GetTotalBlocks()
 	logging.DbBlockCount.WithLabelValues("total_blocks_in_db").Set(float64(totalBlocks))
 
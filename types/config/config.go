@@ -53,6 +53,12 @@ func DefaultConfig() Config {
 		panic(err)
 	}
 
+	// Include the default configuration of all the registered modules
+	bz, err = appendDefaultModulesConfigs(bz)
+	if err != nil {
+		panic(err)
+	}
+
 	cfg.bytes = bz
 	return cfg
 }
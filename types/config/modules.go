@@ -0,0 +1,166 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ModuleConfig represents the configuration of a single module
+type ModuleConfig interface {
+	// Validate returns an error if the configuration is not valid
+	Validate() error
+}
+
+// moduleConfigRegistration contains the data of a module configuration that has been registered
+type moduleConfigRegistration struct {
+	key           string
+	defaultConfig func() ModuleConfig
+	required      bool
+}
+
+var (
+	modulesConfigsMu sync.RWMutex
+	modulesConfigs   = map[string]moduleConfigRegistration{}
+)
+
+// RegisterModuleConfig registers the configuration of the module having the given name.
+// The configuration is read from the section having the given key, and decoded into the value returned
+// by defaultConfig. For this reason, defaultConfig must return a new pointer each time it is called, and the
+// pointed value should contain the default values to be used for the fields that are not specified.
+// NOTE. This should be called inside the init function of the package defining the module.
+func RegisterModuleConfig(moduleName string, key string, defaultConfig func() ModuleConfig) {
+	registerModuleConfig(moduleName, key, defaultConfig, false)
+}
+
+// RegisterRequiredModuleConfig registers the configuration of the module having the given name, in the same way
+// RegisterModuleConfig does. Differently from it, the section having the given key must be present inside the
+// configuration when the module is enabled, and defaultConfig is only used to provide the values of the
+// fields that are not specified inside it.
+// NOTE. This should be called inside the init function of the package defining the module.
+func RegisterRequiredModuleConfig(moduleName string, key string, defaultConfig func() ModuleConfig) {
+	registerModuleConfig(moduleName, key, defaultConfig, true)
+}
+
+func registerModuleConfig(moduleName string, key string, defaultConfig func() ModuleConfig, required bool) {
+	modulesConfigsMu.Lock()
+	defer modulesConfigsMu.Unlock()
+
+	modulesConfigs[moduleName] = moduleConfigRegistration{
+		key:           key,
+		defaultConfig: defaultConfig,
+		required:      required,
+	}
+}
+
+// getModuleConfigRegistration returns the registration of the configuration of the module having the given name
+func getModuleConfigRegistration(moduleName string) (moduleConfigRegistration, bool) {
+	modulesConfigsMu.RLock()
+	defer modulesConfigsMu.RUnlock()
+
+	registration, found := modulesConfigs[moduleName]
+	return registration, found
+}
+
+// getModulesConfigsRegistrations returns all the registered modules configurations, sorted by their key
+func getModulesConfigsRegistrations() []moduleConfigRegistration {
+	modulesConfigsMu.RLock()
+	defer modulesConfigsMu.RUnlock()
+
+	registrations := make([]moduleConfigRegistration, 0, len(modulesConfigs))
+	for _, registration := range modulesConfigs {
+		registrations = append(registrations, registration)
+	}
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].key < registrations[j].key
+	})
+	return registrations
+}
+
+// GetModuleConfig parses and validates the configuration of the module having the given name.
+// If the configuration section is not present, the default configuration is returned instead.
+// An error is returned if the module configuration has not been registered, if the section is required but
+// not present, if the section contains unknown fields or if the configuration is not valid.
+func (c Config) GetModuleConfig(moduleName string) (ModuleConfig, error) {
+	registration, found := getModuleConfigRegistration(moduleName)
+	if !found {
+		return nil, fmt.Errorf("no configuration registered for module %s", moduleName)
+	}
+
+	cfg := registration.defaultConfig()
+
+	var sections map[string]yaml.Node
+	err := yaml.Unmarshal(c.bytes, &sections)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading config: %s", err)
+	}
+
+	section, found := sections[registration.key]
+	found = found && section.Kind != 0 && section.Tag != "!!null"
+	if !found && registration.required {
+		return nil, fmt.Errorf("%s config is not set but module is enabled", registration.key)
+	}
+
+	if found {
+		bz, err := yaml.Marshal(&section)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading %s config: %s", registration.key, err)
+		}
+
+		// Decode the section making sure that there are no unknown fields
+		decoder := yaml.NewDecoder(bytes.NewReader(bz))
+		decoder.KnownFields(true)
+		err = decoder.Decode(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing %s config: %s", registration.key, err)
+		}
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s config: %s", registration.key, err)
+	}
+
+	return cfg, nil
+}
+
+// ValidateModulesConfigs parses and validates the configuration of each enabled module whose configuration
+// has been registered, returning an error if any of them is not valid
+func (c Config) ValidateModulesConfigs() error {
+	for _, moduleName := range c.Chain.Modules {
+		if _, found := getModuleConfigRegistration(moduleName); !found {
+			continue
+		}
+
+		_, err := c.GetModuleConfig(moduleName)
+		if err != nil {
+			return fmt.Errorf("error while reading %s module configuration: %s", moduleName, err)
+		}
+	}
+	return nil
+}
+
+// appendDefaultModulesConfigs appends the default configuration of each registered module to the given YAML bytes
+func appendDefaultModulesConfigs(bz []byte) ([]byte, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(bz, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	root := document.Content[0]
+	for _, registration := range getModulesConfigsRegistrations() {
+		var value yaml.Node
+		err = value.Encode(registration.defaultConfig())
+		if err != nil {
+			return nil, fmt.Errorf("error while encoding %s default config: %s", registration.key, err)
+		}
+
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: registration.key}, &value)
+	}
+
+	return yaml.Marshal(&document)
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// testModuleConfig represents a ModuleConfig used for testing purposes
+type testModuleConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	URL     string `yaml:"url"`
+}
+
+func (c *testModuleConfig) Validate() error {
+	if c.URL == "" {
+		return fmt.Errorf("missing url")
+	}
+	return nil
+}
+
+func TestConfig_GetModuleConfig(t *testing.T) {
+	RegisterModuleConfig("test", "test_module", func() ModuleConfig {
+		return &testModuleConfig{URL: "http://localhost"}
+	})
+
+	testCases := []struct {
+		name      string
+		data      string
+		shouldErr bool
+		expected  *testModuleConfig
+	}{
+		{
+			name:     "missing section returns the default config",
+			data:     `chain: { modules: [ test ] }`,
+			expected: &testModuleConfig{URL: "http://localhost"},
+		},
+		{
+			name: "section values override the default ones",
+			data: `
+chain: { modules: [ test ] }
+test_module:
+  enabled: true
+`,
+			expected: &testModuleConfig{Enabled: true, URL: "http://localhost"},
+		},
+		{
+			name: "unknown fields return error",
+			data: `
+chain: { modules: [ test ] }
+test_module:
+  enabled: true
+  unknown: 1
+`,
+			shouldErr: true,
+		},
+		{
+			name: "invalid config returns error",
+			data: `
+chain: { modules: [ test ] }
+test_module:
+  url: ""
+`,
+			shouldErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := DefaultConfigParser([]byte(tc.data))
+			require.NoError(t, err)
+
+			moduleCfg, err := cfg.GetModuleConfig("test")
+			if tc.shouldErr {
+				require.Error(t, err)
+				require.Error(t, cfg.ValidateModulesConfigs())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, moduleCfg)
+				require.NoError(t, cfg.ValidateModulesConfigs())
+			}
+		})
+	}
+
+	// The default config should contain the default section of the registered module
+	bz, err := DefaultConfig().GetBytes()
+	require.NoError(t, err)
+
+	var sections map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(bz, &sections))
+	require.Equal(t, map[string]interface{}{"enabled": false, "url": "http://localhost"}, sections["test_module"])
+}
+
+func TestConfig_GetRequiredModuleConfig(t *testing.T) {
+	RegisterRequiredModuleConfig("required", "required_module", func() ModuleConfig {
+		return &testModuleConfig{URL: "http://localhost"}
+	})
+
+	// A missing required section should return an error
+	cfg, err := DefaultConfigParser([]byte(`chain: { modules: [ required ] }`))
+	require.NoError(t, err)
+
+	_, err = cfg.GetModuleConfig("required")
+	require.EqualError(t, err, "required_module config is not set but module is enabled")
+	require.Error(t, cfg.ValidateModulesConfigs())
+
+	// The default values should be used for the fields that are not set
+	cfg, err = DefaultConfigParser([]byte(`
+chain: { modules: [ required ] }
+required_module:
+  enabled: true
+`))
+	require.NoError(t, err)
+
+	moduleCfg, err := cfg.GetModuleConfig("required")
+	require.NoError(t, err)
+	require.Equal(t, &testModuleConfig{Enabled: true, URL: "http://localhost"}, moduleCfg)
+}
@@ -298,3 +298,82 @@ func (msg *StandardMessage) UnmarshalJSON(data []byte) error {
 func (msg *StandardMessage) MarshalJSON() ([]byte, error) {
 	return msg.Bytes, nil
 }
+
+// -------------------------------------------------------------------------------------------------------------------
+
+// DecodedMessage represents a message that has been decoded into its concrete type.
+// It wraps the original message, so that its raw bytes are still available.
+type DecodedMessage struct {
+	Message
+
+	// Value contains the JSON representation of the concrete message, with its nested Any values resolved
+	Value json.RawMessage
+
+	// DecodeError contains the error that occurred while decoding the message, if any
+	DecodeError string
+}
+
+// NewDecodedMessage allows to build a new DecodedMessage instance
+func NewDecodedMessage(msg Message, value json.RawMessage, decodeError string) *DecodedMessage {
+	return &DecodedMessage{
+		Message:     msg,
+		Value:       value,
+		DecodeError: decodeError,
+	}
+}
+
+// MarshalJSON allows to marshal a DecodedMessage into the JSON representation of the original message
+func (msg *DecodedMessage) MarshalJSON() ([]byte, error) {
+	return msg.GetBytes(), nil
+}
+
+// -------------------------------------------------------------------------------------------------------------------
+
+// AddressRole represents the role that an address has inside a message
+type AddressRole string
+
+const (
+	AddressRoleSigner    AddressRole = "signer"
+	AddressRoleSender    AddressRole = "sender"
+	AddressRoleRecipient AddressRole = "recipient"
+	AddressRoleValidator AddressRole = "validator"
+	AddressRoleGranter   AddressRole = "granter"
+	AddressRoleGrantee   AddressRole = "grantee"
+
+	// AddressRoleInvolved is used when the role of the address inside the message cannot be determined
+	AddressRoleInvolved AddressRole = "involved"
+)
+
+// MessageAddress contains an address involved by a message, along with its role inside the message
+type MessageAddress struct {
+	Address string
+	Role    AddressRole
+}
+
+// NewMessageAddress allows to build a new MessageAddress instance
+func NewMessageAddress(address string, role AddressRole) MessageAddress {
+	return MessageAddress{
+		Address: address,
+		Role:    role,
+	}
+}
+
+// AddressMessage represents a single message involving a given address
+type AddressMessage struct {
+	Address  string
+	Height   int64
+	TxHash   string
+	MsgIndex int
+	Role     AddressRole
+}
+
+// NewAddressMessage allows to build a new AddressMessage instance
+func NewAddressMessage(address string, height int64, txHash string, msgIndex int, role AddressRole) AddressMessage {
+	return AddressMessage{
+		Address:  address,
+		Height:   height,
+		TxHash:   txHash,
+		MsgIndex: msgIndex,
+		Role:     role,
+	}
+}
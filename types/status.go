@@ -0,0 +1,52 @@
+package types
+
+import (
+	"sync"
+)
+
+// ParsingStatus keeps track of the parsing progress, and can be safely shared among different goroutines
+type ParsingStatus struct {
+	mu            sync.RWMutex
+	queue         HeightQueue
+	workerHeights map[int]int64
+}
+
+// NewParsingStatus allows to build a new ParsingStatus instance
+func NewParsingStatus() *ParsingStatus {
+	return &ParsingStatus{
+		workerHeights: map[int]int64{},
+	}
+}
+
+// SetQueue sets the queue from which the workers read the heights to be parsed
+func (s *ParsingStatus) SetQueue(queue HeightQueue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queue = queue
+}
+
+// GetQueueDepth returns the number of heights waiting to be parsed inside the queue
+func (s *ParsingStatus) GetQueueDepth() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.queue)
+}
+
+// SetWorkerHeight sets the last height parsed by the worker having the given index
+func (s *ParsingStatus) SetWorkerHeight(index int, height int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workerHeights[index] = height
+}
+
+// GetWorkersHeights returns the last height parsed by each worker, indexed by the worker index
+func (s *ParsingStatus) GetWorkersHeights() map[int]int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	heights := make(map[int]int64, len(s.workerHeights))
+	for index, height := range s.workerHeights {
+		heights[index] = height
+	}
+	return heights
+}